@@ -3,6 +3,7 @@ package tcgcollector
 import (
 	"context"
 	"fmt"
+	"iter"
 	"net/http"
 	"net/url"
 	"time"
@@ -50,16 +51,29 @@ func (c *Client) ListAuditLogEntries(ctx context.Context, params *ListAuditLogEn
 	}
 
 	var result ListResponse[AuditLogEntry]
-	if err := c.doRequest(ctx, http.MethodGet, path, nil, &result); err != nil {
+	if err := c.doRequest(ctx, "ListAuditLogEntries", http.MethodGet, path, nil, &result); err != nil {
 		return nil, err
 	}
 	return &result, nil
 }
 
+// AllAuditLogEntries iterates over all audit log entries matching params, fetching pages as needed
+func (c *Client) AllAuditLogEntries(ctx context.Context, params *ListAuditLogEntriesParams, opts ...PageOption) iter.Seq2[AuditLogEntry, error] {
+	var p ListAuditLogEntriesParams
+	if params != nil {
+		p = *params
+	}
+	return paginate(ctx, p.Page, func(ctx context.Context, page int) (Page[AuditLogEntry], error) {
+		pageParams := p
+		pageParams.Page = &page
+		return c.ListAuditLogEntries(ctx, &pageParams)
+	}, opts...)
+}
+
 // GetAuditLogEntry gets a single audit log entry by ID
 func (c *Client) GetAuditLogEntry(ctx context.Context, id int) (*AuditLogEntry, error) {
 	var result AuditLogEntry
-	if err := c.doRequest(ctx, http.MethodGet, fmt.Sprintf("/api/audit-log/%d", id), nil, &result); err != nil {
+	if err := c.doRequest(ctx, "GetAuditLogEntry", http.MethodGet, fmt.Sprintf("/api/audit-log/%d", id), nil, &result); err != nil {
 		return nil, err
 	}
 	return &result, nil
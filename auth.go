@@ -8,7 +8,7 @@ import (
 // Login authenticates a user and returns a JWT token
 func (c *Client) Login(ctx context.Context, request *LoginRequest) (*LoginResponse, error) {
 	var response LoginResponse
-	if err := c.doRequest(ctx, http.MethodPost, "/api/auth/login", request, &response); err != nil {
+	if err := c.doRequest(ctx, "Login", http.MethodPost, "/api/auth/login", request, &response); err != nil {
 		return nil, err
 	}
 
@@ -18,7 +18,7 @@ func (c *Client) Login(ctx context.Context, request *LoginRequest) (*LoginRespon
 // Register creates a new user account
 func (c *Client) Register(ctx context.Context, request *RegisterRequest) (*RegisterResponse, error) {
 	var response RegisterResponse
-	if err := c.doRequest(ctx, http.MethodPost, "/api/auth/register", request, &response); err != nil {
+	if err := c.doRequest(ctx, "Register", http.MethodPost, "/api/auth/register", request, &response); err != nil {
 		return nil, err
 	}
 
@@ -27,13 +27,13 @@ func (c *Client) Register(ctx context.Context, request *RegisterRequest) (*Regis
 
 // Logout invalidates the current JWT token
 func (c *Client) Logout(ctx context.Context) error {
-	return c.doRequest(ctx, http.MethodPost, "/api/auth/logout", nil, nil)
+	return c.doRequest(ctx, "Logout", http.MethodPost, "/api/auth/logout", nil, nil)
 }
 
 // RefreshToken refreshes the current JWT token
 func (c *Client) RefreshToken(ctx context.Context) (*LoginResponse, error) {
 	var response LoginResponse
-	if err := c.doRequest(ctx, http.MethodPost, "/api/auth/refresh", nil, &response); err != nil {
+	if err := c.doRequest(ctx, "RefreshToken", http.MethodPost, "/api/auth/refresh", nil, &response); err != nil {
 		return nil, err
 	}
 
@@ -0,0 +1,70 @@
+package tcgcollector
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemoryCacheEvictsLeastRecentlyUsed(t *testing.T) {
+	cache := NewMemoryCache(2)
+	cache.Set("a", &CacheEntry{Body: []byte("a")})
+	cache.Set("b", &CacheEntry{Body: []byte("b")})
+
+	// Reading a makes b the least recently used entry
+	_, ok := cache.Get("a")
+	assert.True(t, ok)
+	cache.Set("c", &CacheEntry{Body: []byte("c")})
+
+	_, ok = cache.Get("b")
+	assert.False(t, ok)
+	entry, ok := cache.Get("a")
+	assert.True(t, ok)
+	assert.Equal(t, []byte("a"), entry.Body)
+	assert.Equal(t, 2, cache.Len())
+
+	cache.Delete("a")
+	_, ok = cache.Get("a")
+	assert.False(t, ok)
+	assert.Equal(t, 1, cache.Len())
+}
+
+func TestDiskCache(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "cache")
+	cache, err := NewDiskCache(dir)
+	assert.NoError(t, err)
+
+	_, ok := cache.Get("/api/currencies")
+	assert.False(t, ok)
+
+	entry := &CacheEntry{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Etag": {`"v1"`}},
+		Body:       []byte(`[{"id": 1}]`),
+	}
+	cache.Set("/api/currencies", entry)
+
+	// Entries are read back by a new cache in the same directory
+	cache, err = NewDiskCache(dir)
+	assert.NoError(t, err)
+	got, ok := cache.Get("/api/currencies")
+	assert.True(t, ok)
+	assert.Equal(t, entry.Body, got.Body)
+	assert.Equal(t, `"v1"`, got.ETag())
+
+	cache.Delete("/api/currencies")
+	_, ok = cache.Get("/api/currencies")
+	assert.False(t, ok)
+}
+
+func TestDiskCacheIgnoresCorruptEntries(t *testing.T) {
+	cache, err := NewDiskCache(t.TempDir())
+	assert.NoError(t, err)
+
+	assert.NoError(t, os.WriteFile(cache.path("/api/currencies"), []byte("not json"), 0o644))
+	_, ok := cache.Get("/api/currencies")
+	assert.False(t, ok)
+}
@@ -0,0 +1,148 @@
+package tcgcollector
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// CallOption configures the calls made with a context returned by WithCallOptions
+type CallOption func(*callOptions)
+
+type callOptions struct {
+	capture        *responseCapture
+	header         http.Header
+	timeout        *time.Duration
+	token          string
+	disableCache   bool
+	disableRetries bool
+}
+
+type callOptionsKey struct{}
+
+// WithCallOptions returns a context that applies opts to every call made with
+// it, in addition to the options already stored in ctx
+func WithCallOptions(ctx context.Context, opts ...CallOption) context.Context {
+	o := callOptionsFromContext(ctx)
+	o.header = o.header.Clone()
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return context.WithValue(ctx, callOptionsKey{}, o)
+}
+
+// callOptionsFromContext returns the call options stored in ctx
+func callOptionsFromContext(ctx context.Context) callOptions {
+	o, _ := ctx.Value(callOptionsKey{}).(callOptions)
+	return o
+}
+
+// withoutCallOptions returns a context for requests that the client makes on
+// its own behalf, such as logging in, which must not inherit the caller's options
+func withoutCallOptions(ctx context.Context) context.Context {
+	return context.WithValue(ctx, callOptionsKey{}, callOptions{})
+}
+
+// SetHeader sends an additional header with the requests of a call, replacing
+// any value set by the client
+func SetHeader(key, value string) CallOption {
+	return func(o *callOptions) {
+		if o.header == nil {
+			o.header = make(http.Header)
+		}
+		o.header.Set(key, value)
+	}
+}
+
+// Timeout overrides the timeout of the client's HTTP client for each request
+// of a call. A timeout of zero or less disables the timeout
+func Timeout(timeout time.Duration) CallOption {
+	return func(o *callOptions) {
+		o.timeout = &timeout
+	}
+}
+
+// BearerToken sends token instead of the client's API key or token source,
+// e.g. to act on behalf of a tenant of a multi-tenant server. Responses to
+// calls with different tokens are cached separately
+func BearerToken(token string) CallOption {
+	return func(o *callOptions) {
+		o.token = token
+	}
+}
+
+// DisableCache bypasses the response cache and conditional requests for a call
+func DisableCache() CallOption {
+	return func(o *callOptions) {
+		o.disableCache = true
+	}
+}
+
+// DisableRetries sends the request of a call only once, regardless of the retry policy
+func DisableRetries() CallOption {
+	return func(o *callOptions) {
+		o.disableRetries = true
+	}
+}
+
+// ResponseMeta describes the HTTP response of a call
+type ResponseMeta struct {
+	// StatusCode is the status code of the final response, or 0 if there was none
+	StatusCode int
+	// Header contains the headers of the final response
+	Header http.Header
+	// Duration is the time taken by the call, including retries
+	Duration time.Duration
+	// Attempts is the number of requests sent, or 0 if the response was served from a cache
+	Attempts int
+}
+
+// RequestID returns the ID the API assigned to the final request
+func (m *ResponseMeta) RequestID() string {
+	return m.Header.Get("X-Request-Id")
+}
+
+// CaptureResponse stores the metadata of the HTTP response of a call in meta.
+// When several calls are made with the same context, meta describes the last
+// one to finish. Calls may run concurrently, e.g. when pages are prefetched,
+// but meta must only be read once they have returned
+//
+//	var meta tcgcollector.ResponseMeta
+//	card, err := client.GetCard(tcgcollector.WithCallOptions(ctx, tcgcollector.CaptureResponse(&meta)), id)
+func CaptureResponse(meta *ResponseMeta) CallOption {
+	return func(o *callOptions) {
+		o.capture = &responseCapture{meta: meta}
+	}
+}
+
+// responseCapture serializes the writes of concurrent calls to a ResponseMeta
+type responseCapture struct {
+	mu   sync.Mutex
+	meta *ResponseMeta
+}
+
+// captureResponse stores the response metadata of a call made with ctx if it
+// was requested with CaptureResponse
+func captureResponse(ctx context.Context, resp *Response, err error, duration time.Duration) {
+	capture := callOptionsFromContext(ctx).capture
+	if capture == nil {
+		return
+	}
+
+	meta := ResponseMeta{Duration: duration}
+	var apiErr *APIError
+	switch {
+	case resp != nil:
+		meta.StatusCode = resp.StatusCode
+		meta.Header = resp.Header.Clone()
+		meta.Attempts = resp.attempts
+	case errors.As(err, &apiErr):
+		meta.StatusCode = apiErr.StatusCode
+	}
+
+	capture.mu.Lock()
+	defer capture.mu.Unlock()
+	*capture.meta = meta
+}
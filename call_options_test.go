@@ -0,0 +1,210 @@
+package tcgcollector
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCaptureResponse(t *testing.T) {
+	var requests int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Request-Id", "req-123")
+		w.Header().Set("X-RateLimit-Remaining", "42")
+		if atomic.AddInt32(&requests, 1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`{"id": 1, "name": "Pikachu"}`))
+	}))
+	defer ts.Close()
+
+	client := NewClient("test-api-key",
+		WithBaseURL(ts.URL),
+		WithRetryPolicy(&RetryPolicy{MaxRetries: 1, BaseDelay: time.Millisecond, MaxDelay: time.Millisecond}),
+	)
+
+	var meta ResponseMeta
+	ctx := WithCallOptions(context.Background(), CaptureResponse(&meta))
+	card, err := client.GetCard(ctx, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, "Pikachu", card.Name)
+
+	assert.Equal(t, http.StatusOK, meta.StatusCode)
+	assert.Equal(t, "42", meta.Header.Get("X-RateLimit-Remaining"))
+	assert.Equal(t, "req-123", meta.RequestID())
+	assert.Equal(t, 2, meta.Attempts)
+	assert.Positive(t, meta.Duration)
+}
+
+func TestCaptureResponseError(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Request-Id", "req-404")
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer ts.Close()
+
+	client := NewClient("test-api-key", WithBaseURL(ts.URL))
+
+	var meta ResponseMeta
+	_, err := client.GetCard(WithCallOptions(context.Background(), CaptureResponse(&meta)), 1)
+	assert.True(t, IsNotFound(err))
+	assert.Equal(t, http.StatusNotFound, meta.StatusCode)
+	assert.Equal(t, "req-404", meta.RequestID())
+	assert.Equal(t, 1, meta.Attempts)
+}
+
+func TestCaptureResponseFromCache(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Cache-Control", "max-age=60")
+		w.Write([]byte(`{"id": 1}`))
+	}))
+	defer ts.Close()
+
+	client := NewClient("test-api-key", WithBaseURL(ts.URL), WithResponseCache(ResponseCacheConfig{TTL: time.Hour}))
+	var meta ResponseMeta
+	ctx := WithCallOptions(context.Background(), CaptureResponse(&meta))
+
+	_, err := client.GetCard(ctx, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, meta.Attempts)
+
+	// Options added later keep the capture, and cached responses report no attempts
+	_, err = client.GetCard(WithCallOptions(ctx), 1)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, meta.StatusCode)
+	assert.Equal(t, "max-age=60", meta.Header.Get("Cache-Control"))
+	assert.Equal(t, 0, meta.Attempts)
+}
+
+func TestCaptureResponseWithPrefetch(t *testing.T) {
+	ts, requests := newPagedServer(t, "/api/cards", 80, 10)
+	defer ts.Close()
+
+	client := NewClient("test-api-key", WithBaseURL(ts.URL))
+	var meta ResponseMeta
+	ctx := WithCallOptions(context.Background(), CaptureResponse(&meta))
+
+	// Prefetched pages record their responses concurrently
+	cards, err := Collect(client.AllCards(ctx, nil, WithPrefetch(8)))
+	assert.NoError(t, err)
+	assert.Len(t, cards, 80)
+	assert.Equal(t, int32(8), atomic.LoadInt32(requests))
+	assert.Equal(t, http.StatusOK, meta.StatusCode)
+	assert.Equal(t, 1, meta.Attempts)
+}
+
+func TestCallOptionHeaders(t *testing.T) {
+	var headers []http.Header
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		headers = append(headers, r.Header.Clone())
+		w.Write([]byte(`{"id": 1}`))
+	}))
+	defer ts.Close()
+
+	client := NewClient("test-api-key", WithBaseURL(ts.URL), WithResponseCache(ResponseCacheConfig{TTL: time.Hour}))
+	ctx := WithCallOptions(context.Background(), SetHeader("X-Tenant", "shop-1"), SetHeader("Accept", "application/vnd.tcg+json"))
+	_, err := client.GetCard(ctx, 1)
+	assert.NoError(t, err)
+
+	// Options are added to those already in the context without changing them
+	_, err = client.GetCard(WithCallOptions(ctx, SetHeader("X-Tenant", "shop-2")), 1)
+	assert.NoError(t, err)
+	_, err = client.GetCard(ctx, 1)
+	assert.NoError(t, err)
+
+	// Responses to requests with different headers are cached separately
+	assert.Len(t, headers, 2)
+	assert.Equal(t, "shop-1", headers[0].Get("X-Tenant"))
+	assert.Equal(t, "application/vnd.tcg+json", headers[0].Get("Accept"))
+	assert.Equal(t, "Bearer test-api-key", headers[0].Get("Authorization"))
+	assert.Equal(t, "shop-2", headers[1].Get("X-Tenant"))
+}
+
+func TestCallOptionTimeout(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(50 * time.Millisecond)
+		w.Write([]byte(`{"id": 1}`))
+	}))
+	defer ts.Close()
+
+	client := NewClient("test-api-key", WithBaseURL(ts.URL), WithHTTPClient(&http.Client{Timeout: 10 * time.Millisecond}))
+	_, err := client.GetCard(context.Background(), 1)
+	assert.Error(t, err)
+
+	_, err = client.GetCard(WithCallOptions(context.Background(), Timeout(time.Second)), 1)
+	assert.NoError(t, err)
+	_, err = client.GetCard(WithCallOptions(context.Background(), Timeout(0)), 1)
+	assert.NoError(t, err)
+
+	// The client's HTTP client is not modified
+	assert.Equal(t, 10*time.Millisecond, client.httpClient.Timeout)
+}
+
+func TestCallOptionBearerToken(t *testing.T) {
+	ts, logins, _ := newAuthServer(t, time.Hour)
+	defer ts.Close()
+
+	var tokens []string
+	tokenServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		tokens = append(tokens, r.Header.Get("Authorization"))
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer tokenServer.Close()
+
+	source := NewSessionTokenSource(NewClient("", WithBaseURL(ts.URL)), "ash", "pikachu")
+	client := NewClient("", WithBaseURL(tokenServer.URL), WithTokenSource(source))
+
+	// The token source is neither used nor invalidated for calls with their own token
+	_, err := client.GetHealth(WithCallOptions(context.Background(), BearerToken("tenant-token")))
+	assert.True(t, IsUnauthorized(err))
+	assert.Equal(t, []string{"Bearer tenant-token"}, tokens)
+	assert.Equal(t, int32(0), atomic.LoadInt32(logins))
+}
+
+func TestCallOptionDisableRetries(t *testing.T) {
+	var requests int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer ts.Close()
+
+	client := NewClient("test-api-key",
+		WithBaseURL(ts.URL),
+		WithRetryPolicy(&RetryPolicy{MaxRetries: 3, BaseDelay: time.Millisecond, MaxDelay: time.Millisecond}),
+	)
+	_, err := client.GetCard(WithCallOptions(context.Background(), DisableRetries()), 1)
+	assert.True(t, IsServerError(err))
+	assert.Equal(t, int32(1), atomic.LoadInt32(&requests))
+}
+
+func TestCallOptionDisableCache(t *testing.T) {
+	var requests int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		assert.Empty(t, r.Header.Get("If-None-Match"))
+		w.Header().Set("ETag", `"v1"`)
+		w.Write([]byte(`{"id": 1}`))
+	}))
+	defer ts.Close()
+
+	store := NewMemoryCache(10)
+	client := NewClient("test-api-key",
+		WithBaseURL(ts.URL),
+		WithConditionalRequests(store),
+		WithResponseCache(ResponseCacheConfig{TTL: time.Hour}),
+	)
+	ctx := WithCallOptions(context.Background(), DisableCache())
+	for i := 0; i < 2; i++ {
+		_, err := client.GetCard(ctx, 1)
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, int32(2), atomic.LoadInt32(&requests))
+	assert.Equal(t, 0, store.Len())
+}
@@ -19,7 +19,7 @@ type CardCondition struct {
 // ListCardConditions retrieves a list of card conditions
 func (c *Client) ListCardConditions(ctx context.Context) ([]CardCondition, error) {
 	var response []CardCondition
-	if err := c.doRequest(ctx, http.MethodGet, "/api/card-conditions", nil, &response); err != nil {
+	if err := c.doRequest(ctx, "ListCardConditions", http.MethodGet, "/api/card-conditions", nil, &response); err != nil {
 		return nil, err
 	}
 	return response, nil
@@ -28,7 +28,7 @@ func (c *Client) ListCardConditions(ctx context.Context) ([]CardCondition, error
 // GetCardCondition retrieves a single card condition by ID
 func (c *Client) GetCardCondition(ctx context.Context, id int) (*CardCondition, error) {
 	var response CardCondition
-	if err := c.doRequest(ctx, http.MethodGet, fmt.Sprintf("/api/card-conditions/%d", id), nil, &response); err != nil {
+	if err := c.doRequest(ctx, "GetCardCondition", http.MethodGet, fmt.Sprintf("/api/card-conditions/%d", id), nil, &response); err != nil {
 		return nil, err
 	}
 	return &response, nil
@@ -16,7 +16,7 @@ type CardDatabaseLogEntry struct {
 // ListCardDatabaseLogEntries retrieves a list of card database log entries
 func (c *Client) ListCardDatabaseLogEntries(ctx context.Context) ([]CardDatabaseLogEntry, error) {
 	var response []CardDatabaseLogEntry
-	if err := c.doRequest(ctx, http.MethodGet, "/api/card-database-log", nil, &response); err != nil {
+	if err := c.doRequest(ctx, "ListCardDatabaseLogEntries", http.MethodGet, "/api/card-database-log", nil, &response); err != nil {
 		return nil, err
 	}
 	return response, nil
@@ -24,5 +24,5 @@ func (c *Client) ListCardDatabaseLogEntries(ctx context.Context) ([]CardDatabase
 
 // PruneCardDatabaseLog prunes old card database log entries
 func (c *Client) PruneCardDatabaseLog(ctx context.Context) error {
-	return c.doRequest(ctx, http.MethodPost, "/api/card-database-log/prune", nil, nil)
+	return c.doRequest(ctx, "PruneCardDatabaseLog", http.MethodPost, "/api/card-database-log/prune", nil, nil)
 }
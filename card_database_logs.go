@@ -3,6 +3,7 @@ package tcgcollector
 import (
 	"context"
 	"fmt"
+	"iter"
 	"net/http"
 	"net/url"
 	"time"
@@ -15,13 +16,7 @@ type ListCardDatabaseLogsParams struct {
 }
 
 // ListCardDatabaseLogsResponse represents the response from listing card database logs
-type ListCardDatabaseLogsResponse struct {
-	Items          []CardDatabaseLog `json:"items"`
-	ItemCount      int               `json:"itemCount"`
-	TotalItemCount int               `json:"totalItemCount"`
-	Page           int               `json:"page"`
-	PageCount      int               `json:"pageCount"`
-}
+type ListCardDatabaseLogsResponse = ListResponse[CardDatabaseLog]
 
 // CardDatabaseLog represents a log entry in the card database
 type CardDatabaseLog struct {
@@ -36,6 +31,23 @@ type CardDatabaseLog struct {
 
 // ListCardDatabaseLogs retrieves a list of card database logs
 func (c *Client) ListCardDatabaseLogs(ctx context.Context, params *ListCardDatabaseLogsParams) (*ListCardDatabaseLogsResponse, error) {
+	var response ListCardDatabaseLogsResponse
+	if err := c.doRequest(ctx, "ListCardDatabaseLogs", http.MethodGet, cardDatabaseLogsPath(params), nil, &response); err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
+
+// StreamCardDatabaseLogs iterates over a page of card database logs while the
+// response is being read, so large pages are never held in memory at once.
+// Every iteration sends the request again
+func (c *Client) StreamCardDatabaseLogs(ctx context.Context, params *ListCardDatabaseLogsParams) iter.Seq2[CardDatabaseLog, error] {
+	return streamItems[CardDatabaseLog](ctx, c, newOperation("StreamCardDatabaseLogs", http.MethodGet, cardDatabaseLogsPath(params), nil))
+}
+
+// cardDatabaseLogsPath returns the path for listing card database logs with params
+func cardDatabaseLogsPath(params *ListCardDatabaseLogsParams) string {
 	path := "/api/card-database-logs"
 	if params != nil {
 		query := url.Values{}
@@ -49,19 +61,26 @@ func (c *Client) ListCardDatabaseLogs(ctx context.Context, params *ListCardDatab
 			path = fmt.Sprintf("%s?%s", path, query.Encode())
 		}
 	}
+	return path
+}
 
-	var response ListCardDatabaseLogsResponse
-	if err := c.doRequest(ctx, http.MethodGet, path, nil, &response); err != nil {
-		return nil, err
+// AllCardDatabaseLogs iterates over all card database logs, fetching pages as needed
+func (c *Client) AllCardDatabaseLogs(ctx context.Context, params *ListCardDatabaseLogsParams, opts ...PageOption) iter.Seq2[CardDatabaseLog, error] {
+	var p ListCardDatabaseLogsParams
+	if params != nil {
+		p = *params
 	}
-
-	return &response, nil
+	return paginate(ctx, p.Page, func(ctx context.Context, page int) (Page[CardDatabaseLog], error) {
+		pageParams := p
+		pageParams.Page = &page
+		return c.ListCardDatabaseLogs(ctx, &pageParams)
+	}, opts...)
 }
 
 // GetCardDatabaseLog retrieves a single card database log by ID
 func (c *Client) GetCardDatabaseLog(ctx context.Context, id int) (*CardDatabaseLog, error) {
 	var response CardDatabaseLog
-	if err := c.doRequest(ctx, http.MethodGet, fmt.Sprintf("/api/card-database-logs/%d", id), nil, &response); err != nil {
+	if err := c.doRequest(ctx, "GetCardDatabaseLog", http.MethodGet, fmt.Sprintf("/api/card-database-logs/%d", id), nil, &response); err != nil {
 		return nil, err
 	}
 
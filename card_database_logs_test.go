@@ -135,3 +135,23 @@ func TestGetCardDatabaseLog(t *testing.T) {
 	assert.Equal(t, "create", result.Action)
 	assert.Equal(t, "Card created", result.Details)
 }
+
+func TestStreamCardDatabaseLogs(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/card-database-logs", r.URL.Path)
+		assert.Equal(t, "2", r.URL.Query().Get("page"))
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"items": [{"id": 1, "action": "create"}, {"id": 2, "action": "update"}]}`))
+	}))
+	defer ts.Close()
+
+	client := NewClient("test-api-key", WithBaseURL(ts.URL))
+
+	page := 2
+	var ids []int
+	for log, err := range client.StreamCardDatabaseLogs(context.Background(), &ListCardDatabaseLogsParams{Page: &page}) {
+		assert.NoError(t, err)
+		ids = append(ids, log.ID)
+	}
+	assert.Equal(t, []int{1, 2}, ids)
+}
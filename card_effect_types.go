@@ -19,7 +19,7 @@ type CardEffectType struct {
 // ListCardEffectTypes retrieves a list of card effect types
 func (c *Client) ListCardEffectTypes(ctx context.Context) ([]CardEffectType, error) {
 	var response []CardEffectType
-	if err := c.doRequest(ctx, http.MethodGet, "/api/card-effect-types", nil, &response); err != nil {
+	if err := c.doRequest(ctx, "ListCardEffectTypes", http.MethodGet, "/api/card-effect-types", nil, &response); err != nil {
 		return nil, err
 	}
 	return response, nil
@@ -28,7 +28,7 @@ func (c *Client) ListCardEffectTypes(ctx context.Context) ([]CardEffectType, err
 // GetCardEffectType retrieves a single card effect type by ID
 func (c *Client) GetCardEffectType(ctx context.Context, id int) (*CardEffectType, error) {
 	var response CardEffectType
-	if err := c.doRequest(ctx, http.MethodGet, fmt.Sprintf("/api/card-effect-types/%d", id), nil, &response); err != nil {
+	if err := c.doRequest(ctx, "GetCardEffectType", http.MethodGet, fmt.Sprintf("/api/card-effect-types/%d", id), nil, &response); err != nil {
 		return nil, err
 	}
 	return &response, nil
@@ -19,7 +19,7 @@ type CardFormat struct {
 // ListCardFormats retrieves a list of card formats
 func (c *Client) ListCardFormats(ctx context.Context) ([]CardFormat, error) {
 	var response []CardFormat
-	if err := c.doRequest(ctx, http.MethodGet, "/api/card-formats", nil, &response); err != nil {
+	if err := c.doRequest(ctx, "ListCardFormats", http.MethodGet, "/api/card-formats", nil, &response); err != nil {
 		return nil, err
 	}
 	return response, nil
@@ -28,7 +28,7 @@ func (c *Client) ListCardFormats(ctx context.Context) ([]CardFormat, error) {
 // GetCardFormat retrieves a single card format by ID
 func (c *Client) GetCardFormat(ctx context.Context, id int) (*CardFormat, error) {
 	var response CardFormat
-	if err := c.doRequest(ctx, http.MethodGet, fmt.Sprintf("/api/card-formats/%d", id), nil, &response); err != nil {
+	if err := c.doRequest(ctx, "GetCardFormat", http.MethodGet, fmt.Sprintf("/api/card-formats/%d", id), nil, &response); err != nil {
 		return nil, err
 	}
 	return &response, nil
@@ -20,7 +20,7 @@ type CardGradeCompany struct {
 // ListCardGradeCompanies retrieves a list of card grading companies
 func (c *Client) ListCardGradeCompanies(ctx context.Context) ([]CardGradeCompany, error) {
 	var response []CardGradeCompany
-	if err := c.doRequest(ctx, http.MethodGet, "/api/card-grade-companies", nil, &response); err != nil {
+	if err := c.doRequest(ctx, "ListCardGradeCompanies", http.MethodGet, "/api/card-grade-companies", nil, &response); err != nil {
 		return nil, err
 	}
 	return response, nil
@@ -29,7 +29,7 @@ func (c *Client) ListCardGradeCompanies(ctx context.Context) ([]CardGradeCompany
 // GetCardGradeCompany retrieves a single card grading company by ID
 func (c *Client) GetCardGradeCompany(ctx context.Context, id int) (*CardGradeCompany, error) {
 	var response CardGradeCompany
-	if err := c.doRequest(ctx, http.MethodGet, fmt.Sprintf("/api/card-grade-companies/%d", id), nil, &response); err != nil {
+	if err := c.doRequest(ctx, "GetCardGradeCompany", http.MethodGet, fmt.Sprintf("/api/card-grade-companies/%d", id), nil, &response); err != nil {
 		return nil, err
 	}
 	return &response, nil
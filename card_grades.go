@@ -3,6 +3,7 @@ package tcgcollector
 import (
 	"context"
 	"fmt"
+	"iter"
 	"net/http"
 	"net/url"
 	"time"
@@ -57,16 +58,29 @@ func (c *Client) ListCardGrades(ctx context.Context, params *ListCardGradesParam
 	}
 
 	var result ListResponse[CardGrade]
-	if err := c.doRequest(ctx, http.MethodGet, path, nil, &result); err != nil {
+	if err := c.doRequest(ctx, "ListCardGrades", http.MethodGet, path, nil, &result); err != nil {
 		return nil, err
 	}
 	return &result, nil
 }
 
+// AllCardGrades iterates over all card grades matching params, fetching pages as needed
+func (c *Client) AllCardGrades(ctx context.Context, params *ListCardGradesParams, opts ...PageOption) iter.Seq2[CardGrade, error] {
+	var p ListCardGradesParams
+	if params != nil {
+		p = *params
+	}
+	return paginate(ctx, p.Page, func(ctx context.Context, page int) (Page[CardGrade], error) {
+		pageParams := p
+		pageParams.Page = &page
+		return c.ListCardGrades(ctx, &pageParams)
+	}, opts...)
+}
+
 // GetCardGrade retrieves a single card grade by ID
 func (c *Client) GetCardGrade(ctx context.Context, id int) (*CardGrade, error) {
 	var result CardGrade
-	if err := c.doRequest(ctx, http.MethodGet, fmt.Sprintf("/api/card-grades/%d", id), nil, &result); err != nil {
+	if err := c.doRequest(ctx, "GetCardGrade", http.MethodGet, fmt.Sprintf("/api/card-grades/%d", id), nil, &result); err != nil {
 		return nil, err
 	}
 	return &result, nil
@@ -75,7 +89,7 @@ func (c *Client) GetCardGrade(ctx context.Context, id int) (*CardGrade, error) {
 // CreateCardGrade creates a new card grade
 func (c *Client) CreateCardGrade(ctx context.Context, grade *CardGrade) (*CardGrade, error) {
 	var result CardGrade
-	if err := c.doRequest(ctx, http.MethodPost, "/api/card-grades", grade, &result); err != nil {
+	if err := c.doIdempotentRequest(ctx, "CreateCardGrade", http.MethodPost, "/api/card-grades", grade, &result); err != nil {
 		return nil, err
 	}
 	return &result, nil
@@ -84,7 +98,7 @@ func (c *Client) CreateCardGrade(ctx context.Context, grade *CardGrade) (*CardGr
 // UpdateCardGrade updates an existing card grade
 func (c *Client) UpdateCardGrade(ctx context.Context, id int, grade *CardGrade) (*CardGrade, error) {
 	var result CardGrade
-	if err := c.doRequest(ctx, http.MethodPut, fmt.Sprintf("/api/card-grades/%d", id), grade, &result); err != nil {
+	if err := c.doRequest(ctx, "UpdateCardGrade", http.MethodPut, fmt.Sprintf("/api/card-grades/%d", id), grade, &result); err != nil {
 		return nil, err
 	}
 	return &result, nil
@@ -92,5 +106,5 @@ func (c *Client) UpdateCardGrade(ctx context.Context, id int, grade *CardGrade)
 
 // DeleteCardGrade deletes a card grade
 func (c *Client) DeleteCardGrade(ctx context.Context, id int) error {
-	return c.doRequest(ctx, http.MethodDelete, fmt.Sprintf("/api/card-grades/%d", id), nil, nil)
+	return c.doRequest(ctx, "DeleteCardGrade", http.MethodDelete, fmt.Sprintf("/api/card-grades/%d", id), nil, nil)
 }
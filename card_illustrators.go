@@ -20,7 +20,7 @@ type CardIllustrator struct {
 // ListCardIllustrators retrieves a list of card illustrators
 func (c *Client) ListCardIllustrators(ctx context.Context) ([]CardIllustrator, error) {
 	var response []CardIllustrator
-	if err := c.doRequest(ctx, http.MethodGet, "/api/card-illustrators", nil, &response); err != nil {
+	if err := c.doRequest(ctx, "ListCardIllustrators", http.MethodGet, "/api/card-illustrators", nil, &response); err != nil {
 		return nil, err
 	}
 	return response, nil
@@ -29,7 +29,7 @@ func (c *Client) ListCardIllustrators(ctx context.Context) ([]CardIllustrator, e
 // GetCardIllustrator retrieves a single card illustrator by ID
 func (c *Client) GetCardIllustrator(ctx context.Context, id int) (*CardIllustrator, error) {
 	var response CardIllustrator
-	if err := c.doRequest(ctx, http.MethodGet, fmt.Sprintf("/api/card-illustrators/%d", id), nil, &response); err != nil {
+	if err := c.doRequest(ctx, "GetCardIllustrator", http.MethodGet, fmt.Sprintf("/api/card-illustrators/%d", id), nil, &response); err != nil {
 		return nil, err
 	}
 	return &response, nil
@@ -20,7 +20,7 @@ type CardLanguage struct {
 // ListCardLanguages retrieves a list of card languages
 func (c *Client) ListCardLanguages(ctx context.Context) ([]CardLanguage, error) {
 	var response []CardLanguage
-	if err := c.doRequest(ctx, http.MethodGet, "/api/card-languages", nil, &response); err != nil {
+	if err := c.doRequest(ctx, "ListCardLanguages", http.MethodGet, "/api/card-languages", nil, &response); err != nil {
 		return nil, err
 	}
 	return response, nil
@@ -29,7 +29,7 @@ func (c *Client) ListCardLanguages(ctx context.Context) ([]CardLanguage, error)
 // GetCardLanguage retrieves a single card language by ID
 func (c *Client) GetCardLanguage(ctx context.Context, id int) (*CardLanguage, error) {
 	var response CardLanguage
-	if err := c.doRequest(ctx, http.MethodGet, fmt.Sprintf("/api/card-languages/%d", id), nil, &response); err != nil {
+	if err := c.doRequest(ctx, "GetCardLanguage", http.MethodGet, fmt.Sprintf("/api/card-languages/%d", id), nil, &response); err != nil {
 		return nil, err
 	}
 	return &response, nil
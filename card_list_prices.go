@@ -3,6 +3,7 @@ package tcgcollector
 import (
 	"context"
 	"fmt"
+	"iter"
 	"net/http"
 	"net/url"
 	"time"
@@ -15,13 +16,7 @@ type ListCardListPricesParams struct {
 }
 
 // ListCardListPricesResponse represents the response from listing card list prices
-type ListCardListPricesResponse struct {
-	Items          []CardListPrice `json:"items"`
-	ItemCount      int             `json:"itemCount"`
-	TotalItemCount int             `json:"totalItemCount"`
-	Page           int             `json:"page"`
-	PageCount      int             `json:"pageCount"`
-}
+type ListCardListPricesResponse = ListResponse[CardListPrice]
 
 // CardListPrice represents a price for a card list
 type CardListPrice struct {
@@ -51,17 +46,30 @@ func (c *Client) ListCardListPrices(ctx context.Context, params *ListCardListPri
 	}
 
 	var response ListCardListPricesResponse
-	if err := c.doRequest(ctx, http.MethodGet, path, nil, &response); err != nil {
+	if err := c.doRequest(ctx, "ListCardListPrices", http.MethodGet, path, nil, &response); err != nil {
 		return nil, err
 	}
 
 	return &response, nil
 }
 
+// AllCardListPrices iterates over all card list prices, fetching pages as needed
+func (c *Client) AllCardListPrices(ctx context.Context, params *ListCardListPricesParams, opts ...PageOption) iter.Seq2[CardListPrice, error] {
+	var p ListCardListPricesParams
+	if params != nil {
+		p = *params
+	}
+	return paginate(ctx, p.Page, func(ctx context.Context, page int) (Page[CardListPrice], error) {
+		pageParams := p
+		pageParams.Page = &page
+		return c.ListCardListPrices(ctx, &pageParams)
+	}, opts...)
+}
+
 // GetCardListPrice retrieves a single card list price by ID
 func (c *Client) GetCardListPrice(ctx context.Context, id int) (*CardListPrice, error) {
 	var response CardListPrice
-	if err := c.doRequest(ctx, http.MethodGet, fmt.Sprintf("/api/card-list-prices/%d", id), nil, &response); err != nil {
+	if err := c.doRequest(ctx, "GetCardListPrice", http.MethodGet, fmt.Sprintf("/api/card-list-prices/%d", id), nil, &response); err != nil {
 		return nil, err
 	}
 
@@ -3,6 +3,7 @@ package tcgcollector
 import (
 	"context"
 	"fmt"
+	"iter"
 	"net/http"
 	"net/url"
 	"time"
@@ -15,13 +16,7 @@ type ListCardListReferencesParams struct {
 }
 
 // ListCardListReferencesResponse represents the response from listing card list references
-type ListCardListReferencesResponse struct {
-	Items          []CardListReference `json:"items"`
-	ItemCount      int                 `json:"itemCount"`
-	TotalItemCount int                 `json:"totalItemCount"`
-	Page           int                 `json:"page"`
-	PageCount      int                 `json:"pageCount"`
-}
+type ListCardListReferencesResponse = ListResponse[CardListReference]
 
 // CardListReference represents a reference to a card list
 type CardListReference struct {
@@ -50,17 +45,30 @@ func (c *Client) ListCardListReferences(ctx context.Context, params *ListCardLis
 	}
 
 	var response ListCardListReferencesResponse
-	if err := c.doRequest(ctx, http.MethodGet, path, nil, &response); err != nil {
+	if err := c.doRequest(ctx, "ListCardListReferences", http.MethodGet, path, nil, &response); err != nil {
 		return nil, err
 	}
 
 	return &response, nil
 }
 
+// AllCardListReferences iterates over all card list references, fetching pages as needed
+func (c *Client) AllCardListReferences(ctx context.Context, params *ListCardListReferencesParams, opts ...PageOption) iter.Seq2[CardListReference, error] {
+	var p ListCardListReferencesParams
+	if params != nil {
+		p = *params
+	}
+	return paginate(ctx, p.Page, func(ctx context.Context, page int) (Page[CardListReference], error) {
+		pageParams := p
+		pageParams.Page = &page
+		return c.ListCardListReferences(ctx, &pageParams)
+	}, opts...)
+}
+
 // GetCardListReference retrieves a single card list reference by ID
 func (c *Client) GetCardListReference(ctx context.Context, id int) (*CardListReference, error) {
 	var response CardListReference
-	if err := c.doRequest(ctx, http.MethodGet, fmt.Sprintf("/api/card-list-references/%d", id), nil, &response); err != nil {
+	if err := c.doRequest(ctx, "GetCardListReference", http.MethodGet, fmt.Sprintf("/api/card-list-references/%d", id), nil, &response); err != nil {
 		return nil, err
 	}
 
@@ -30,7 +30,7 @@ type CardListEntry struct {
 // ListCardLists retrieves a list of card lists
 func (c *Client) ListCardLists(ctx context.Context) ([]CardList, error) {
 	var response []CardList
-	if err := c.doRequest(ctx, http.MethodGet, "/api/card-lists", nil, &response); err != nil {
+	if err := c.doRequest(ctx, "ListCardLists", http.MethodGet, "/api/card-lists", nil, &response); err != nil {
 		return nil, err
 	}
 	return response, nil
@@ -39,7 +39,7 @@ func (c *Client) ListCardLists(ctx context.Context) ([]CardList, error) {
 // GetCardList retrieves a single card list by ID
 func (c *Client) GetCardList(ctx context.Context, id int) (*CardList, error) {
 	var response CardList
-	if err := c.doRequest(ctx, http.MethodGet, fmt.Sprintf("/api/card-lists/%d", id), nil, &response); err != nil {
+	if err := c.doRequest(ctx, "GetCardList", http.MethodGet, fmt.Sprintf("/api/card-lists/%d", id), nil, &response); err != nil {
 		return nil, err
 	}
 	return &response, nil
@@ -48,7 +48,7 @@ func (c *Client) GetCardList(ctx context.Context, id int) (*CardList, error) {
 // ListCardListEntries retrieves entries for a card list
 func (c *Client) ListCardListEntries(ctx context.Context, cardListID int) ([]CardListEntry, error) {
 	var response []CardListEntry
-	if err := c.doRequest(ctx, http.MethodGet, fmt.Sprintf("/api/card-lists/%d/entries", cardListID), nil, &response); err != nil {
+	if err := c.doRequest(ctx, "ListCardListEntries", http.MethodGet, fmt.Sprintf("/api/card-lists/%d/entries", cardListID), nil, &response); err != nil {
 		return nil, err
 	}
 	return response, nil
@@ -56,15 +56,15 @@ func (c *Client) ListCardListEntries(ctx context.Context, cardListID int) ([]Car
 
 // RecalculateCardCounts recalculates card counts for all card lists
 func (c *Client) RecalculateCardCounts(ctx context.Context) error {
-	return c.doRequest(ctx, http.MethodPost, "/api/card-lists/recalculate-card-counts", nil, nil)
+	return c.doRequest(ctx, "RecalculateCardCounts", http.MethodPost, "/api/card-lists/recalculate-card-counts", nil, nil)
 }
 
 // RegenerateCardListSlugs regenerates slugs for all card lists
 func (c *Client) RegenerateCardListSlugs(ctx context.Context) error {
-	return c.doRequest(ctx, http.MethodPost, "/api/card-lists/regenerate-slugs", nil, nil)
+	return c.doRequest(ctx, "RegenerateCardListSlugs", http.MethodPost, "/api/card-lists/regenerate-slugs", nil, nil)
 }
 
 // BulkReplaceCardListEntries replaces all entries in a card list
 func (c *Client) BulkReplaceCardListEntries(ctx context.Context, cardListID int, entries []CardListEntry) error {
-	return c.doRequest(ctx, http.MethodPost, fmt.Sprintf("/api/card-lists/%d/entries/bulk-replace", cardListID), entries, nil)
+	return c.doRequest(ctx, "BulkReplaceCardListEntries", http.MethodPost, fmt.Sprintf("/api/card-lists/%d/entries/bulk-replace", cardListID), entries, nil)
 }
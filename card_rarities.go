@@ -18,7 +18,7 @@ type CardRarity struct {
 // ListCardRarities retrieves a list of card rarities
 func (c *Client) ListCardRarities(ctx context.Context) ([]CardRarity, error) {
 	var response []CardRarity
-	if err := c.doRequest(ctx, http.MethodGet, "/api/card-rarities", nil, &response); err != nil {
+	if err := c.doRequest(ctx, "ListCardRarities", http.MethodGet, "/api/card-rarities", nil, &response); err != nil {
 		return nil, err
 	}
 	return response, nil
@@ -27,7 +27,7 @@ func (c *Client) ListCardRarities(ctx context.Context) ([]CardRarity, error) {
 // GetCardRarity retrieves a single card rarity by ID
 func (c *Client) GetCardRarity(ctx context.Context, id int) (*CardRarity, error) {
 	var response CardRarity
-	if err := c.doRequest(ctx, http.MethodGet, fmt.Sprintf("/api/card-rarities/%d", id), nil, &response); err != nil {
+	if err := c.doRequest(ctx, "GetCardRarity", http.MethodGet, fmt.Sprintf("/api/card-rarities/%d", id), nil, &response); err != nil {
 		return nil, err
 	}
 	return &response, nil
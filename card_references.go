@@ -3,6 +3,7 @@ package tcgcollector
 import (
 	"context"
 	"fmt"
+	"iter"
 	"net/http"
 	"net/url"
 	"time"
@@ -15,13 +16,7 @@ type ListCardReferencesParams struct {
 }
 
 // ListCardReferencesResponse represents the response from listing card references
-type ListCardReferencesResponse struct {
-	Items          []CardReference `json:"items"`
-	ItemCount      int             `json:"itemCount"`
-	TotalItemCount int             `json:"totalItemCount"`
-	Page           int             `json:"page"`
-	PageCount      int             `json:"pageCount"`
-}
+type ListCardReferencesResponse = ListResponse[CardReference]
 
 // CardReference represents a reference to a card
 type CardReference struct {
@@ -50,17 +45,30 @@ func (c *Client) ListCardReferences(ctx context.Context, params *ListCardReferen
 	}
 
 	var response ListCardReferencesResponse
-	if err := c.doRequest(ctx, http.MethodGet, path, nil, &response); err != nil {
+	if err := c.doRequest(ctx, "ListCardReferences", http.MethodGet, path, nil, &response); err != nil {
 		return nil, err
 	}
 
 	return &response, nil
 }
 
+// AllCardReferences iterates over all card references, fetching pages as needed
+func (c *Client) AllCardReferences(ctx context.Context, params *ListCardReferencesParams, opts ...PageOption) iter.Seq2[CardReference, error] {
+	var p ListCardReferencesParams
+	if params != nil {
+		p = *params
+	}
+	return paginate(ctx, p.Page, func(ctx context.Context, page int) (Page[CardReference], error) {
+		pageParams := p
+		pageParams.Page = &page
+		return c.ListCardReferences(ctx, &pageParams)
+	}, opts...)
+}
+
 // GetCardReference retrieves a single card reference by ID
 func (c *Client) GetCardReference(ctx context.Context, id int) (*CardReference, error) {
 	var response CardReference
-	if err := c.doRequest(ctx, http.MethodGet, fmt.Sprintf("/api/card-references/%d", id), nil, &response); err != nil {
+	if err := c.doRequest(ctx, "GetCardReference", http.MethodGet, fmt.Sprintf("/api/card-references/%d", id), nil, &response); err != nil {
 		return nil, err
 	}
 
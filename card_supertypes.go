@@ -19,7 +19,7 @@ type CardSupertype struct {
 // ListCardSupertypes retrieves a list of card supertypes
 func (c *Client) ListCardSupertypes(ctx context.Context) ([]CardSupertype, error) {
 	var response []CardSupertype
-	if err := c.doRequest(ctx, http.MethodGet, "/api/card-supertypes", nil, &response); err != nil {
+	if err := c.doRequest(ctx, "ListCardSupertypes", http.MethodGet, "/api/card-supertypes", nil, &response); err != nil {
 		return nil, err
 	}
 	return response, nil
@@ -28,7 +28,7 @@ func (c *Client) ListCardSupertypes(ctx context.Context) ([]CardSupertype, error
 // GetCardSupertype retrieves a single card supertype by ID
 func (c *Client) GetCardSupertype(ctx context.Context, id int) (*CardSupertype, error) {
 	var response CardSupertype
-	if err := c.doRequest(ctx, http.MethodGet, fmt.Sprintf("/api/card-supertypes/%d", id), nil, &response); err != nil {
+	if err := c.doRequest(ctx, "GetCardSupertype", http.MethodGet, fmt.Sprintf("/api/card-supertypes/%d", id), nil, &response); err != nil {
 		return nil, err
 	}
 	return &response, nil
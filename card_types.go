@@ -19,7 +19,7 @@ type CardType struct {
 // ListCardTypes retrieves a list of card types
 func (c *Client) ListCardTypes(ctx context.Context) ([]CardType, error) {
 	var response []CardType
-	if err := c.doRequest(ctx, http.MethodGet, "/api/card-types", nil, &response); err != nil {
+	if err := c.doRequest(ctx, "ListCardTypes", http.MethodGet, "/api/card-types", nil, &response); err != nil {
 		return nil, err
 	}
 	return response, nil
@@ -28,7 +28,7 @@ func (c *Client) ListCardTypes(ctx context.Context) ([]CardType, error) {
 // GetCardType retrieves a single card type by ID
 func (c *Client) GetCardType(ctx context.Context, id int) (*CardType, error) {
 	var response CardType
-	if err := c.doRequest(ctx, http.MethodGet, fmt.Sprintf("/api/card-types/%d", id), nil, &response); err != nil {
+	if err := c.doRequest(ctx, "GetCardType", http.MethodGet, fmt.Sprintf("/api/card-types/%d", id), nil, &response); err != nil {
 		return nil, err
 	}
 	return &response, nil
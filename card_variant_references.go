@@ -3,6 +3,7 @@ package tcgcollector
 import (
 	"context"
 	"fmt"
+	"iter"
 	"net/http"
 	"net/url"
 	"time"
@@ -15,13 +16,7 @@ type ListCardVariantReferencesParams struct {
 }
 
 // ListCardVariantReferencesResponse represents the response from listing card variant references
-type ListCardVariantReferencesResponse struct {
-	Items          []CardVariantReference `json:"items"`
-	ItemCount      int                    `json:"itemCount"`
-	TotalItemCount int                    `json:"totalItemCount"`
-	Page           int                    `json:"page"`
-	PageCount      int                    `json:"pageCount"`
-}
+type ListCardVariantReferencesResponse = ListResponse[CardVariantReference]
 
 // CardVariantReference represents a reference to a card variant
 type CardVariantReference struct {
@@ -50,17 +45,30 @@ func (c *Client) ListCardVariantReferences(ctx context.Context, params *ListCard
 	}
 
 	var response ListCardVariantReferencesResponse
-	if err := c.doRequest(ctx, http.MethodGet, path, nil, &response); err != nil {
+	if err := c.doRequest(ctx, "ListCardVariantReferences", http.MethodGet, path, nil, &response); err != nil {
 		return nil, err
 	}
 
 	return &response, nil
 }
 
+// AllCardVariantReferences iterates over all card variant references, fetching pages as needed
+func (c *Client) AllCardVariantReferences(ctx context.Context, params *ListCardVariantReferencesParams, opts ...PageOption) iter.Seq2[CardVariantReference, error] {
+	var p ListCardVariantReferencesParams
+	if params != nil {
+		p = *params
+	}
+	return paginate(ctx, p.Page, func(ctx context.Context, page int) (Page[CardVariantReference], error) {
+		pageParams := p
+		pageParams.Page = &page
+		return c.ListCardVariantReferences(ctx, &pageParams)
+	}, opts...)
+}
+
 // GetCardVariantReference retrieves a single card variant reference by ID
 func (c *Client) GetCardVariantReference(ctx context.Context, id int) (*CardVariantReference, error) {
 	var response CardVariantReference
-	if err := c.doRequest(ctx, http.MethodGet, fmt.Sprintf("/api/card-variant-references/%d", id), nil, &response); err != nil {
+	if err := c.doRequest(ctx, "GetCardVariantReference", http.MethodGet, fmt.Sprintf("/api/card-variant-references/%d", id), nil, &response); err != nil {
 		return nil, err
 	}
 
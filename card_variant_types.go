@@ -3,6 +3,7 @@ package tcgcollector
 import (
 	"context"
 	"fmt"
+	"iter"
 	"net/http"
 	"net/url"
 )
@@ -23,13 +24,7 @@ type ListCardVariantTypesParams struct {
 }
 
 // ListCardVariantTypesResponse represents the response from listing card variant types
-type ListCardVariantTypesResponse struct {
-	Items          []CardVariantType `json:"items"`
-	ItemCount      int               `json:"itemCount"`
-	TotalItemCount int               `json:"totalItemCount"`
-	Page           int               `json:"page"`
-	PageCount      int               `json:"pageCount"`
-}
+type ListCardVariantTypesResponse = ListResponse[CardVariantType]
 
 // ListCardVariantTypes retrieves a list of card variant types
 func (c *Client) ListCardVariantTypes(ctx context.Context, params *ListCardVariantTypesParams) (*ListCardVariantTypesResponse, error) {
@@ -48,17 +43,30 @@ func (c *Client) ListCardVariantTypes(ctx context.Context, params *ListCardVaria
 	}
 
 	var response ListCardVariantTypesResponse
-	if err := c.doRequest(ctx, http.MethodGet, path, nil, &response); err != nil {
+	if err := c.doRequest(ctx, "ListCardVariantTypes", http.MethodGet, path, nil, &response); err != nil {
 		return nil, err
 	}
 
 	return &response, nil
 }
 
+// AllCardVariantTypes iterates over all card variant types, fetching pages as needed
+func (c *Client) AllCardVariantTypes(ctx context.Context, params *ListCardVariantTypesParams, opts ...PageOption) iter.Seq2[CardVariantType, error] {
+	var p ListCardVariantTypesParams
+	if params != nil {
+		p = *params
+	}
+	return paginate(ctx, p.Page, func(ctx context.Context, page int) (Page[CardVariantType], error) {
+		pageParams := p
+		pageParams.Page = &page
+		return c.ListCardVariantTypes(ctx, &pageParams)
+	}, opts...)
+}
+
 // GetCardVariantType retrieves a single card variant type by ID
 func (c *Client) GetCardVariantType(ctx context.Context, id int) (*CardVariantType, error) {
 	var response CardVariantType
-	if err := c.doRequest(ctx, http.MethodGet, fmt.Sprintf("/api/card-variant-types/%d", id), nil, &response); err != nil {
+	if err := c.doRequest(ctx, "GetCardVariantType", http.MethodGet, fmt.Sprintf("/api/card-variant-types/%d", id), nil, &response); err != nil {
 		return nil, err
 	}
 
@@ -68,7 +76,7 @@ func (c *Client) GetCardVariantType(ctx context.Context, id int) (*CardVariantTy
 // CreateCardVariantType creates a new card variant type
 func (c *Client) CreateCardVariantType(ctx context.Context, variantType *CardVariantType) (*CardVariantType, error) {
 	var response CardVariantType
-	if err := c.doRequest(ctx, http.MethodPost, "/api/card-variant-types", variantType, &response); err != nil {
+	if err := c.doIdempotentRequest(ctx, "CreateCardVariantType", http.MethodPost, "/api/card-variant-types", variantType, &response); err != nil {
 		return nil, err
 	}
 
@@ -78,7 +86,7 @@ func (c *Client) CreateCardVariantType(ctx context.Context, variantType *CardVar
 // UpdateCardVariantType updates an existing card variant type
 func (c *Client) UpdateCardVariantType(ctx context.Context, id int, variantType *CardVariantType) (*CardVariantType, error) {
 	var response CardVariantType
-	if err := c.doRequest(ctx, http.MethodPut, fmt.Sprintf("/api/card-variant-types/%d", id), variantType, &response); err != nil {
+	if err := c.doRequest(ctx, "UpdateCardVariantType", http.MethodPut, fmt.Sprintf("/api/card-variant-types/%d", id), variantType, &response); err != nil {
 		return nil, err
 	}
 
@@ -87,5 +95,5 @@ func (c *Client) UpdateCardVariantType(ctx context.Context, id int, variantType
 
 // DeleteCardVariantType deletes a card variant type
 func (c *Client) DeleteCardVariantType(ctx context.Context, id int) error {
-	return c.doRequest(ctx, http.MethodDelete, fmt.Sprintf("/api/card-variant-types/%d", id), nil, nil)
+	return c.doRequest(ctx, "DeleteCardVariantType", http.MethodDelete, fmt.Sprintf("/api/card-variant-types/%d", id), nil, nil)
 }
@@ -3,6 +3,7 @@ package tcgcollector
 import (
 	"context"
 	"fmt"
+	"iter"
 	"net/http"
 	"net/url"
 )
@@ -39,16 +40,29 @@ func (c *Client) ListCardVariants(ctx context.Context, params *ListCardVariantsP
 	}
 
 	var result ListResponse[CardVariant]
-	if err := c.doRequest(ctx, http.MethodGet, path, nil, &result); err != nil {
+	if err := c.doRequest(ctx, "ListCardVariants", http.MethodGet, path, nil, &result); err != nil {
 		return nil, err
 	}
 	return &result, nil
 }
 
+// AllCardVariants iterates over all card variants matching params, fetching pages as needed
+func (c *Client) AllCardVariants(ctx context.Context, params *ListCardVariantsParams, opts ...PageOption) iter.Seq2[CardVariant, error] {
+	var p ListCardVariantsParams
+	if params != nil {
+		p = *params
+	}
+	return paginate(ctx, p.Page, func(ctx context.Context, page int) (Page[CardVariant], error) {
+		pageParams := p
+		pageParams.Page = &page
+		return c.ListCardVariants(ctx, &pageParams)
+	}, opts...)
+}
+
 // GetCardVariant gets a single card variant by ID
 func (c *Client) GetCardVariant(ctx context.Context, id int) (*CardVariant, error) {
 	var result CardVariant
-	if err := c.doRequest(ctx, http.MethodGet, fmt.Sprintf("/api/card-variants/%d", id), nil, &result); err != nil {
+	if err := c.doRequest(ctx, "GetCardVariant", http.MethodGet, fmt.Sprintf("/api/card-variants/%d", id), nil, &result); err != nil {
 		return nil, err
 	}
 	return &result, nil
@@ -57,7 +71,7 @@ func (c *Client) GetCardVariant(ctx context.Context, id int) (*CardVariant, erro
 // CreateCardVariant creates a new card variant
 func (c *Client) CreateCardVariant(ctx context.Context, variant *CardVariant) (*CardVariant, error) {
 	var result CardVariant
-	if err := c.doRequest(ctx, http.MethodPost, "/api/card-variants", variant, &result); err != nil {
+	if err := c.doIdempotentRequest(ctx, "CreateCardVariant", http.MethodPost, "/api/card-variants", variant, &result); err != nil {
 		return nil, err
 	}
 	return &result, nil
@@ -66,7 +80,7 @@ func (c *Client) CreateCardVariant(ctx context.Context, variant *CardVariant) (*
 // UpdateCardVariant updates an existing card variant
 func (c *Client) UpdateCardVariant(ctx context.Context, id int, variant *CardVariant) (*CardVariant, error) {
 	var result CardVariant
-	if err := c.doRequest(ctx, http.MethodPut, fmt.Sprintf("/api/card-variants/%d", id), variant, &result); err != nil {
+	if err := c.doRequest(ctx, "UpdateCardVariant", http.MethodPut, fmt.Sprintf("/api/card-variants/%d", id), variant, &result); err != nil {
 		return nil, err
 	}
 	return &result, nil
@@ -74,13 +88,13 @@ func (c *Client) UpdateCardVariant(ctx context.Context, id int, variant *CardVar
 
 // DeleteCardVariant deletes a card variant
 func (c *Client) DeleteCardVariant(ctx context.Context, id int) error {
-	return c.doRequest(ctx, http.MethodDelete, fmt.Sprintf("/api/card-variants/%d", id), nil, nil)
+	return c.doRequest(ctx, "DeleteCardVariant", http.MethodDelete, fmt.Sprintf("/api/card-variants/%d", id), nil, nil)
 }
 
 // GetCardVariantPrices gets the price history for a card variant
 func (c *Client) GetCardVariantPrices(ctx context.Context, variantID int) (*ListResponse[CardVariantPrice], error) {
 	var result ListResponse[CardVariantPrice]
-	if err := c.doRequest(ctx, http.MethodGet, fmt.Sprintf("/api/card-variants/%d/prices", variantID), nil, &result); err != nil {
+	if err := c.doRequest(ctx, "GetCardVariantPrices", http.MethodGet, fmt.Sprintf("/api/card-variants/%d/prices", variantID), nil, &result); err != nil {
 		return nil, err
 	}
 	return &result, nil
@@ -88,5 +102,5 @@ func (c *Client) GetCardVariantPrices(ctx context.Context, variantID int) (*List
 
 // RecalculateComputedAndCachedValues recalculates computed and cached values for all card variants
 func (c *Client) RecalculateComputedAndCachedValues(ctx context.Context) error {
-	return c.doRequest(ctx, http.MethodPost, "/api/card-variants/recalculate-computed-and-cached-values", nil, nil)
+	return c.doRequest(ctx, "RecalculateComputedAndCachedValues", http.MethodPost, "/api/card-variants/recalculate-computed-and-cached-values", nil, nil)
 }
@@ -3,6 +3,7 @@ package tcgcollector
 import (
 	"context"
 	"fmt"
+	"iter"
 	"net/http"
 	"net/url"
 )
@@ -47,16 +48,29 @@ func (c *Client) ListCards(ctx context.Context, params *ListCardsParams) (*ListR
 	}
 
 	var result ListResponse[Card]
-	if err := c.doRequest(ctx, http.MethodGet, path, nil, &result); err != nil {
+	if err := c.doRequest(ctx, "ListCards", http.MethodGet, path, nil, &result); err != nil {
 		return nil, err
 	}
 	return &result, nil
 }
 
+// AllCards iterates over all cards matching params, fetching pages as needed
+func (c *Client) AllCards(ctx context.Context, params *ListCardsParams, opts ...PageOption) iter.Seq2[Card, error] {
+	var p ListCardsParams
+	if params != nil {
+		p = *params
+	}
+	return paginate(ctx, p.Page, func(ctx context.Context, page int) (Page[Card], error) {
+		pageParams := p
+		pageParams.Page = &page
+		return c.ListCards(ctx, &pageParams)
+	}, opts...)
+}
+
 // GetCard gets a single card by ID
 func (c *Client) GetCard(ctx context.Context, id int) (*Card, error) {
 	var result Card
-	if err := c.doRequest(ctx, http.MethodGet, fmt.Sprintf("/api/cards/%d", id), nil, &result); err != nil {
+	if err := c.doRequest(ctx, "GetCard", http.MethodGet, fmt.Sprintf("/api/cards/%d", id), nil, &result); err != nil {
 		return nil, err
 	}
 	return &result, nil
@@ -65,7 +79,7 @@ func (c *Client) GetCard(ctx context.Context, id int) (*Card, error) {
 // GetCardPrices gets the price history for a card
 func (c *Client) GetCardPrices(ctx context.Context, cardID int) (*ListResponse[CardPrice], error) {
 	var result ListResponse[CardPrice]
-	if err := c.doRequest(ctx, http.MethodGet, fmt.Sprintf("/api/cards/%d/prices", cardID), nil, &result); err != nil {
+	if err := c.doRequest(ctx, "GetCardPrices", http.MethodGet, fmt.Sprintf("/api/cards/%d/prices", cardID), nil, &result); err != nil {
 		return nil, err
 	}
 	return &result, nil
@@ -73,15 +87,15 @@ func (c *Client) GetCardPrices(ctx context.Context, cardID int) (*ListResponse[C
 
 // RecalculateCachedValues recalculates cached values for all cards
 func (c *Client) RecalculateCachedValues(ctx context.Context) error {
-	return c.doRequest(ctx, http.MethodPost, "/api/cards/recalculate-cached-values", nil, nil)
+	return c.doRequest(ctx, "RecalculateCachedValues", http.MethodPost, "/api/cards/recalculate-cached-values", nil, nil)
 }
 
 // RegenerateSlugs regenerates slugs for all cards
 func (c *Client) RegenerateSlugs(ctx context.Context) error {
-	return c.doRequest(ctx, http.MethodPost, "/api/cards/regenerate-slugs", nil, nil)
+	return c.doRequest(ctx, "RegenerateSlugs", http.MethodPost, "/api/cards/regenerate-slugs", nil, nil)
 }
 
 // RegenerateSurrogateNumbersAndFullNames regenerates surrogate numbers and full names for all cards
 func (c *Client) RegenerateSurrogateNumbersAndFullNames(ctx context.Context) error {
-	return c.doRequest(ctx, http.MethodPost, "/api/cards/regenerate-surrogate-numbers-and-full-names", nil, nil)
+	return c.doRequest(ctx, "RegenerateSurrogateNumbersAndFullNames", http.MethodPost, "/api/cards/regenerate-surrogate-numbers-and-full-names", nil, nil)
 }
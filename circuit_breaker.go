@@ -0,0 +1,268 @@
+package tcgcollector
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+const (
+	defaultFailureThreshold = 5
+	defaultCooldown         = 30 * time.Second
+)
+
+// ErrCircuitOpen is returned without sending a request while the circuit
+// breaker of its host or operation is open
+var ErrCircuitOpen = errors.New("circuit breaker is open")
+
+// CircuitState is the state of a circuit breaker
+type CircuitState int
+
+const (
+	// CircuitClosed lets requests through and counts consecutive failures
+	CircuitClosed CircuitState = iota
+	// CircuitOpen rejects requests with ErrCircuitOpen until the cooldown has passed
+	CircuitOpen
+	// CircuitHalfOpen lets a limited number of trial requests through to
+	// decide whether to close or reopen the circuit
+	CircuitHalfOpen
+)
+
+// String returns the name of the state
+func (s CircuitState) String() string {
+	switch s {
+	case CircuitClosed:
+		return "closed"
+	case CircuitOpen:
+		return "open"
+	case CircuitHalfOpen:
+		return "half-open"
+	}
+	return fmt.Sprintf("CircuitState(%d)", int(s))
+}
+
+// CircuitBreakerConfig configures the circuit breaker enabled by WithCircuitBreaker
+type CircuitBreakerConfig struct {
+	// FailureThreshold is the number of consecutive failures that opens the circuit. It defaults to 5
+	FailureThreshold int
+
+	// Cooldown is how long the circuit stays open before trial requests are
+	// let through. It defaults to 30 seconds
+	Cooldown time.Duration
+
+	// HalfOpenRequests is the number of successful trial requests that closes
+	// the circuit. It defaults to 1
+	HalfOpenRequests int
+
+	// PerOperation keeps a separate circuit for every operation on a host
+	// instead of one circuit per host
+	PerOperation bool
+
+	// IsFailure reports whether the error of a request attempt counts as a
+	// failure. It defaults to DefaultIsCircuitFailure. Attempts that end with a
+	// canceled or expired context and are not failures leave the circuit as it is
+	IsFailure func(err error) bool
+
+	// OnStateChange is called when the circuit identified by key changes state.
+	// The key is the host, followed by the operation name if PerOperation is set.
+	// It is called synchronously and must not make requests with the client
+	OnStateChange func(key string, from, to CircuitState)
+}
+
+// DefaultIsCircuitFailure counts network errors and server errors as failures.
+// Client errors and canceled or expired contexts are not failures of the API
+func DefaultIsCircuitFailure(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode >= 500
+	}
+	return true
+}
+
+// WithCircuitBreaker stops sending requests to a host, or to an operation on a
+// host, after consecutive failures. Requests fail fast with ErrCircuitOpen
+// until the cooldown has passed and trial requests succeed
+func WithCircuitBreaker(config CircuitBreakerConfig) ClientOption {
+	return func(c *Client) {
+		if config.FailureThreshold < 0 || config.Cooldown < 0 || config.HalfOpenRequests < 0 {
+			c.configError(errors.New("invalid circuit breaker: thresholds and cooldown cannot be negative"))
+			return
+		}
+		if config.FailureThreshold == 0 {
+			config.FailureThreshold = defaultFailureThreshold
+		}
+		if config.Cooldown == 0 {
+			config.Cooldown = defaultCooldown
+		}
+		if config.HalfOpenRequests == 0 {
+			config.HalfOpenRequests = 1
+		}
+		if config.IsFailure == nil {
+			config.IsFailure = DefaultIsCircuitFailure
+		}
+		c.circuitBreaker = &circuitBreaker{config: config, circuits: make(map[string]*circuit)}
+	}
+}
+
+// circuitBreaker keeps a circuit per key
+type circuitBreaker struct {
+	config CircuitBreakerConfig
+
+	mu       sync.Mutex
+	circuits map[string]*circuit
+}
+
+// errNotSent records that an allowed attempt was abandoned before its request
+// was sent, e.g. because the token could not be obtained
+var errNotSent = errors.New("request not sent")
+
+// circuitOutcome classifies the outcome of a request attempt
+type circuitOutcome int
+
+const (
+	outcomeSuccess circuitOutcome = iota
+	outcomeFailure
+	// outcomeIgnored is an attempt whose caller gave up, which says nothing about the API
+	outcomeIgnored
+)
+
+// circuit is the state of a single circuit
+type circuit struct {
+	state    CircuitState
+	failures int
+	openedAt time.Time
+	// trials and successes count the trial requests in the half-open state
+	trials    int
+	successes int
+	// generation is incremented on every state change, so that the outcomes
+	// of requests allowed in an earlier state are ignored
+	generation int
+}
+
+// key returns the key of the circuit that a request to host for operation belongs to
+func (cb *circuitBreaker) key(host, operation string) string {
+	if cb.config.PerOperation {
+		return host + " " + operation
+	}
+	return host
+}
+
+// allow reports whether a request may be sent on the circuit identified by
+// key. If it may, the returned function must be called with the outcome
+func (cb *circuitBreaker) allow(key string) (func(err error), error) {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	c, ok := cb.circuits[key]
+	if !ok {
+		c = &circuit{}
+		cb.circuits[key] = c
+	}
+
+	trial := false
+	switch c.state {
+	case CircuitOpen:
+		if time.Since(c.openedAt) < cb.config.Cooldown {
+			return nil, fmt.Errorf("%w: %s", ErrCircuitOpen, key)
+		}
+		cb.setState(key, c, CircuitHalfOpen)
+		fallthrough
+	case CircuitHalfOpen:
+		if c.trials >= cb.config.HalfOpenRequests {
+			return nil, fmt.Errorf("%w: %s", ErrCircuitOpen, key)
+		}
+		c.trials++
+		trial = true
+	}
+
+	generation := c.generation
+	return func(err error) {
+		cb.record(key, c, generation, trial, err)
+	}, nil
+}
+
+// outcome classifies the error of a request attempt
+func (cb *circuitBreaker) outcome(err error) circuitOutcome {
+	switch {
+	case errors.Is(err, errNotSent):
+		return outcomeIgnored
+	case cb.config.IsFailure(err):
+		return outcomeFailure
+	case errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded):
+		return outcomeIgnored
+	}
+	return outcomeSuccess
+}
+
+// record updates the circuit with the outcome of a request
+func (cb *circuitBreaker) record(key string, c *circuit, generation int, trial bool, err error) {
+	outcome := cb.outcome(err)
+
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	// Requests allowed before the last state change neither hold a trial slot
+	// nor decide the current state
+	if generation != c.generation {
+		return
+	}
+	// An ignored trial gives its slot back to the next request
+	if trial {
+		c.trials--
+	}
+	if outcome == outcomeIgnored {
+		return
+	}
+
+	failed := outcome == outcomeFailure
+	switch c.state {
+	case CircuitClosed:
+		if !failed {
+			c.failures = 0
+			return
+		}
+		c.failures++
+		if c.failures >= cb.config.FailureThreshold {
+			cb.setState(key, c, CircuitOpen)
+		}
+	case CircuitHalfOpen:
+		if failed {
+			cb.setState(key, c, CircuitOpen)
+			return
+		}
+		c.successes++
+		if c.successes >= cb.config.HalfOpenRequests {
+			cb.setState(key, c, CircuitClosed)
+		}
+	}
+}
+
+// setState moves the circuit to state and reports the change. It must be called with cb.mu held
+func (cb *circuitBreaker) setState(key string, c *circuit, state CircuitState) {
+	from := c.state
+	c.state = state
+	c.generation++
+	c.trials = 0
+	c.failures = 0
+	c.successes = 0
+	if state == CircuitOpen {
+		c.openedAt = time.Now()
+	}
+	if cb.config.OnStateChange != nil {
+		cb.config.OnStateChange(key, from, state)
+	}
+}
+
+// allowRequest checks the client's circuit breaker before a request attempt to
+// host for op. The returned function records the outcome of the attempt
+func (c *Client) allowRequest(host string, op *Operation) (func(err error), error) {
+	if c.circuitBreaker == nil {
+		return func(error) {}, nil
+	}
+	return c.circuitBreaker.allow(c.circuitBreaker.key(host, op.Name))
+}
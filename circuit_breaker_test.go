@@ -0,0 +1,279 @@
+package tcgcollector
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// stateChanges records the state changes reported by a circuit breaker
+type stateChanges struct {
+	mu      sync.Mutex
+	changes []string
+}
+
+func (s *stateChanges) record(key string, from, to CircuitState) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.changes = append(s.changes, from.String()+" -> "+to.String())
+}
+
+func (s *stateChanges) get() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.changes...)
+}
+
+func TestCircuitBreakerOpensAndCloses(t *testing.T) {
+	var requests int32
+	var status int32 = http.StatusInternalServerError
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.WriteHeader(int(atomic.LoadInt32(&status)))
+		w.Write([]byte(`{"id": 1}`))
+	}))
+	defer ts.Close()
+
+	var keys []string
+	changes := &stateChanges{}
+	client := NewClient("test-api-key", WithBaseURL(ts.URL), WithCircuitBreaker(CircuitBreakerConfig{
+		FailureThreshold: 3,
+		Cooldown:         20 * time.Millisecond,
+		OnStateChange: func(key string, from, to CircuitState) {
+			keys = append(keys, key)
+			changes.record(key, from, to)
+		},
+	}))
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		_, err := client.GetCard(ctx, 1)
+		assert.True(t, IsServerError(err))
+	}
+
+	// The open circuit fails fast without sending a request
+	_, err := client.GetCard(ctx, 1)
+	assert.ErrorIs(t, err, ErrCircuitOpen)
+	assert.Equal(t, int32(3), atomic.LoadInt32(&requests))
+
+	u, _ := url.Parse(ts.URL)
+	assert.Equal(t, u.Host, keys[0])
+	assert.EqualError(t, err, "circuit breaker is open: "+u.Host)
+
+	// After the cooldown a successful trial request closes the circuit
+	atomic.StoreInt32(&status, http.StatusOK)
+	time.Sleep(30 * time.Millisecond)
+	_, err = client.GetCard(ctx, 1)
+	assert.NoError(t, err)
+	_, err = client.GetCard(ctx, 1)
+	assert.NoError(t, err)
+
+	assert.Equal(t, []string{"closed -> open", "open -> half-open", "half-open -> closed"}, changes.get())
+}
+
+func TestCircuitBreakerReopensOnFailedTrial(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer ts.Close()
+
+	changes := &stateChanges{}
+	client := NewClient("test-api-key", WithBaseURL(ts.URL), WithCircuitBreaker(CircuitBreakerConfig{
+		FailureThreshold: 1,
+		Cooldown:         20 * time.Millisecond,
+		OnStateChange:    changes.record,
+	}))
+	ctx := context.Background()
+
+	_, err := client.GetHealth(ctx)
+	assert.True(t, IsServerError(err))
+	time.Sleep(30 * time.Millisecond)
+	_, err = client.GetHealth(ctx)
+	assert.True(t, IsServerError(err))
+	_, err = client.GetHealth(ctx)
+	assert.ErrorIs(t, err, ErrCircuitOpen)
+
+	assert.Equal(t, []string{"closed -> open", "open -> half-open", "half-open -> open"}, changes.get())
+}
+
+func TestCircuitBreakerIgnoresCanceledRequests(t *testing.T) {
+	var slow int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.LoadInt32(&slow) == 1 {
+			<-r.Context().Done()
+			return
+		}
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer ts.Close()
+
+	changes := &stateChanges{}
+	client := NewClient("test-api-key", WithBaseURL(ts.URL), WithCircuitBreaker(CircuitBreakerConfig{
+		FailureThreshold: 2,
+		Cooldown:         20 * time.Millisecond,
+		OnStateChange:    changes.record,
+	}))
+	ctx := context.Background()
+	timedOut := func() {
+		ctx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+		defer cancel()
+		_, err := client.GetHealth(ctx)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	}
+
+	// A timed out request does not reset the consecutive failures
+	_, err := client.GetHealth(ctx)
+	assert.True(t, IsServerError(err))
+	atomic.StoreInt32(&slow, 1)
+	timedOut()
+	atomic.StoreInt32(&slow, 0)
+	_, err = client.GetHealth(ctx)
+	assert.True(t, IsServerError(err))
+	assert.Equal(t, []string{"closed -> open"}, changes.get())
+
+	// A timed out trial neither closes nor reopens the circuit, and the next request is tried instead
+	time.Sleep(30 * time.Millisecond)
+	atomic.StoreInt32(&slow, 1)
+	timedOut()
+	assert.Equal(t, []string{"closed -> open", "open -> half-open"}, changes.get())
+	atomic.StoreInt32(&slow, 0)
+	_, err = client.GetHealth(ctx)
+	assert.True(t, IsServerError(err))
+	assert.Equal(t, []string{"closed -> open", "open -> half-open", "half-open -> open"}, changes.get())
+}
+
+func TestCircuitBreakerFailsFastBeforeRateLimitAndToken(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer ts.Close()
+
+	tokens := &tokenCounter{}
+	client := NewClient("", WithBaseURL(ts.URL),
+		WithTokenSource(tokens),
+		WithRateLimit(2, 1),
+		WithCircuitBreaker(CircuitBreakerConfig{FailureThreshold: 1, Cooldown: time.Hour}),
+	)
+	ctx := context.Background()
+
+	_, err := client.GetHealth(ctx)
+	assert.True(t, IsServerError(err))
+
+	// The open circuit neither waits for the rate limiter nor asks for a token
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		_, err = client.GetHealth(ctx)
+		assert.ErrorIs(t, err, ErrCircuitOpen)
+	}
+	assert.Less(t, time.Since(start), 100*time.Millisecond)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&tokens.calls))
+}
+
+// tokenCounter is a TokenSource that counts the tokens it is asked for
+type tokenCounter struct {
+	calls int32
+}
+
+func (s *tokenCounter) Token(context.Context) (string, error) {
+	atomic.AddInt32(&s.calls, 1)
+	return "token", nil
+}
+
+func TestCircuitBreakerIgnoresTrialsOfEarlierPhases(t *testing.T) {
+	changes := &stateChanges{}
+	cb := &circuitBreaker{
+		config: CircuitBreakerConfig{
+			FailureThreshold: 1,
+			Cooldown:         10 * time.Millisecond,
+			HalfOpenRequests: 2,
+			IsFailure:        DefaultIsCircuitFailure,
+			OnStateChange:    changes.record,
+		},
+		circuits: make(map[string]*circuit),
+	}
+	failure := errors.New("connection refused")
+
+	record, err := cb.allow("host")
+	assert.NoError(t, err)
+	record(failure)
+
+	// One trial of the first half-open phase is still running when the other reopens the circuit
+	time.Sleep(20 * time.Millisecond)
+	slow, err := cb.allow("host")
+	assert.NoError(t, err)
+	record, err = cb.allow("host")
+	assert.NoError(t, err)
+	record(failure)
+
+	// The next half-open phase has all of its slots, and the slow trial does not decide it
+	time.Sleep(20 * time.Millisecond)
+	first, err := cb.allow("host")
+	assert.NoError(t, err)
+	second, err := cb.allow("host")
+	assert.NoError(t, err)
+	slow(nil)
+	first(nil)
+	assert.Equal(t, []string{"closed -> open", "open -> half-open", "half-open -> open", "open -> half-open"}, changes.get())
+	second(nil)
+	assert.Equal(t, []string{"closed -> open", "open -> half-open", "half-open -> open", "open -> half-open", "half-open -> closed"}, changes.get())
+}
+
+func TestCircuitBreakerIgnoresClientErrors(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer ts.Close()
+
+	client := NewClient("test-api-key", WithBaseURL(ts.URL), WithCircuitBreaker(CircuitBreakerConfig{FailureThreshold: 1}))
+	for i := 0; i < 3; i++ {
+		_, err := client.GetCard(context.Background(), 1)
+		assert.True(t, IsNotFound(err))
+	}
+}
+
+func TestCircuitBreakerPerOperation(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/cards/1" {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.Write([]byte(`{"status": "ok"}`))
+	}))
+	defer ts.Close()
+
+	client := NewClient("test-api-key", WithBaseURL(ts.URL), WithCircuitBreaker(CircuitBreakerConfig{
+		FailureThreshold: 1,
+		PerOperation:     true,
+	}))
+	ctx := context.Background()
+
+	_, err := client.GetCard(ctx, 1)
+	assert.True(t, IsServerError(err))
+	_, err = client.GetCard(ctx, 1)
+	assert.ErrorIs(t, err, ErrCircuitOpen)
+
+	// Other operations on the host have their own circuit
+	_, err = client.GetHealth(ctx)
+	assert.NoError(t, err)
+}
+
+func TestDefaultIsCircuitFailure(t *testing.T) {
+	assert.False(t, DefaultIsCircuitFailure(nil))
+	assert.False(t, DefaultIsCircuitFailure(context.Canceled))
+	assert.False(t, DefaultIsCircuitFailure(&APIError{StatusCode: http.StatusTooManyRequests}))
+	assert.True(t, DefaultIsCircuitFailure(&APIError{StatusCode: http.StatusBadGateway}))
+	assert.True(t, DefaultIsCircuitFailure(errors.New("connection refused")))
+}
+
+func TestCircuitBreakerInvalidConfig(t *testing.T) {
+	_, err := NewClientWithOptions("test-api-key", WithCircuitBreaker(CircuitBreakerConfig{Cooldown: -time.Second}))
+	assert.EqualError(t, err, "invalid client configuration: invalid circuit breaker: thresholds and cooldown cannot be negative")
+}
@@ -4,11 +4,17 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"log/slog"
+	"mime"
 	"net/http"
 	"net/url"
+	"slices"
+	"strings"
 	"time"
+	"unicode"
 )
 
 const (
@@ -18,49 +24,152 @@ const (
 
 // Client represents a TCG Collector API client
 type Client struct {
-	baseURL    *url.URL
-	httpClient *http.Client
-	apiKey     string
+	baseURL     *url.URL
+	httpClient  *http.Client
+	apiKey      string
+	tokenSource TokenSource
+	retryPolicy *RetryPolicy
+	rateLimiter rateLimiter
+	middleware  []Middleware
+	logger      *slog.Logger
+	logLevels   LogLevels
+
+	conditionalStore CacheStore
+	responseCache    *responseCache
+	coalescer        *coalescer
+	metrics          MetricsRecorder
+	tracer           Tracer
+	circuitBreaker   *circuitBreaker
+	imageCacheDir    string
+	debug            *debugWriter
+	debugToken       bool
+	dryRun           *Plan
+
+	collectConfigErrors bool
+	configErrors        []error
 }
 
 // ClientOption is a function that configures a Client
 type ClientOption func(*Client)
 
-// NewClient creates a new TCG Collector API client
+// NewClient creates a new TCG Collector API client. It panics if an option is
+// invalid; use NewClientWithOptions to handle invalid configuration as an error
 func NewClient(apiKey string, opts ...ClientOption) *Client {
+	client := newClient(apiKey)
+	for _, opt := range opts {
+		opt(client)
+	}
+
+	return client
+}
+
+// NewClientWithOptions creates a new TCG Collector API client and validates its
+// configuration. Options report invalid values as errors instead of panicking,
+// and all problems are returned together
+func NewClientWithOptions(apiKey string, opts ...ClientOption) (*Client, error) {
+	client := newClient(apiKey)
+	client.collectConfigErrors = true
+	for _, opt := range opts {
+		opt(client)
+	}
+	client.collectConfigErrors = false
+
+	errs := append(client.configErrors, client.validate()...)
+	client.configErrors = nil
+	if len(errs) > 0 {
+		return nil, fmt.Errorf("invalid client configuration: %w", errors.Join(errs...))
+	}
+
+	return client, nil
+}
+
+// newClient creates a client with the default configuration
+func newClient(apiKey string) *Client {
 	baseURL, _ := url.Parse(defaultBaseURL)
-	client := &Client{
+	return &Client{
 		baseURL: baseURL,
 		httpClient: &http.Client{
 			Timeout: defaultTimeout,
 		},
-		apiKey: apiKey,
+		apiKey:    apiKey,
+		logLevels: DefaultLogLevels,
 	}
+}
 
-	for _, opt := range opts {
-		opt(client)
+// configError reports an invalid option. It panics unless the client is being
+// created by NewClientWithOptions
+func (c *Client) configError(err error) {
+	if !c.collectConfigErrors {
+		panic(err.Error())
 	}
+	c.configErrors = append(c.configErrors, err)
+}
 
-	return client
+// validate checks the configuration of the client
+func (c *Client) validate() []error {
+	var errs []error
+
+	if c.baseURL.Host == "" {
+		errs = append(errs, fmt.Errorf("invalid base URL: %s (must include a host)", c.baseURL))
+	}
+
+	if c.httpClient == nil {
+		errs = append(errs, errors.New("HTTP client cannot be nil"))
+	} else if c.httpClient.Timeout < 0 {
+		errs = append(errs, fmt.Errorf("invalid timeout: %s (cannot be negative)", c.httpClient.Timeout))
+	}
+
+	if p := c.retryPolicy; p != nil {
+		if p.MaxRetries < 0 {
+			errs = append(errs, fmt.Errorf("invalid retry policy: max retries %d cannot be negative", p.MaxRetries))
+		}
+		if p.BaseDelay < 0 || p.MaxDelay < 0 || p.MaxRetryAfter < 0 {
+			errs = append(errs, errors.New("invalid retry policy: delays cannot be negative"))
+		}
+		if p.MaxDelay > 0 && p.MaxDelay < p.BaseDelay {
+			errs = append(errs, fmt.Errorf("invalid retry policy: max delay %s is less than base delay %s", p.MaxDelay, p.BaseDelay))
+		}
+	}
+
+	// The API key is unused when a token source is configured
+	if c.tokenSource == nil {
+		if strings.TrimSpace(c.apiKey) != c.apiKey {
+			errs = append(errs, errors.New("invalid API key: contains leading or trailing whitespace"))
+		} else if strings.ContainsFunc(c.apiKey, unicode.IsControl) {
+			errs = append(errs, errors.New("invalid API key: contains control characters"))
+		}
+	}
+
+	return errs
 }
 
 // WithBaseURL sets the base URL for the client
 func WithBaseURL(baseURL string) ClientOption {
 	return func(c *Client) {
-		if baseURL == "" {
-			panic("base URL cannot be empty")
-		}
-		u, err := url.Parse(baseURL)
+		u, err := parseBaseURL(baseURL)
 		if err != nil {
-			panic(fmt.Sprintf("invalid base URL: %v", err))
-		}
-		if u.Scheme != "http" && u.Scheme != "https" {
-			panic(fmt.Sprintf("invalid base URL scheme: %s (must be http or https)", u.Scheme))
+			c.configError(err)
+			return
 		}
 		c.baseURL = u
 	}
 }
 
+// parseBaseURL parses and validates a base URL
+func parseBaseURL(baseURL string) (*url.URL, error) {
+	if baseURL == "" {
+		return nil, errors.New("base URL cannot be empty")
+	}
+	u, err := url.Parse(baseURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid base URL: %v", err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, fmt.Errorf("invalid base URL scheme: %s (must be http or https)", u.Scheme)
+	}
+	return u, nil
+}
+
 // WithHTTPClient sets the HTTP client for the client
 func WithHTTPClient(httpClient *http.Client) ClientOption {
 	return func(c *Client) {
@@ -68,62 +177,331 @@ func WithHTTPClient(httpClient *http.Client) ClientOption {
 	}
 }
 
-// doRequest performs an HTTP request and decodes the response
-func (c *Client) doRequest(ctx context.Context, method, path string, body interface{}, result interface{}) error {
-	var reqBody io.Reader
-	if body != nil {
-		jsonData, err := json.Marshal(body)
+// doRequest performs an HTTP request for the named operation and decodes the response
+func (c *Client) doRequest(ctx context.Context, name, method, path string, body interface{}, result interface{}) error {
+	resp, err := c.perform(ctx, newOperation(name, method, path, body), c.transport())
+	if err != nil {
+		return err
+	}
+
+	return decodeResponse(resp, result)
+}
+
+// decodeResponse decodes the body of a response into result unless result is
+// nil. Responses without content leave result unchanged
+func decodeResponse(resp *Response, result interface{}) error {
+	if result == nil || hasNoContent(resp.StatusCode, resp.Body) {
+		return nil
+	}
+	if err := checkJSON(resp.StatusCode, resp.Header, resp.Body); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	if err := json.NewDecoder(bytes.NewReader(resp.Body)).Decode(result); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return nil
+}
+
+// hasNoContent reports whether a successful response carries no content: its
+// status is 204 or 205, or its body is empty
+func hasNoContent(statusCode int, body []byte) bool {
+	return statusCode == http.StatusNoContent || statusCode == http.StatusResetContent ||
+		len(bytes.TrimSpace(body)) == 0
+}
+
+// checkJSON returns an error describing a response that is not JSON, e.g. an
+// HTML page served by a proxy. Malformed bodies declared as JSON are left to
+// the decoder. Bodies without a media type or declared as text/plain, which
+// servers often send for JSON, must begin like a JSON document
+func checkJSON(statusCode int, header http.Header, head []byte) error {
+	head = bytes.TrimSpace(head)
+	if len(head) == 0 {
+		return nil
+	}
+	contentType := header.Get("Content-Type")
+	switch mediaType, _, _ := mime.ParseMediaType(contentType); {
+	case isJSONContentType(contentType):
+		return nil
+	case (mediaType == "" || mediaType == "text/plain") && isJSONPrefix(head):
+		return nil
+	}
+
+	snippet := string(head)
+	if len(snippet) > maxErrorBodySnippet {
+		snippet = snippet[:maxErrorBodySnippet] + "..."
+	}
+	return fmt.Errorf("%w (status %d, Content-Type %q): %s", ErrNotJSON, statusCode, header.Get("Content-Type"), snippet)
+}
+
+// isJSONPrefix reports whether the start of a body, which may be cut off, is
+// a sequence of JSON values without syntax errors
+func isJSONPrefix(head []byte) bool {
+	dec := json.NewDecoder(bytes.NewReader(head[:min(len(head), maxErrorBodySnippet)]))
+	for {
+		_, err := dec.Token()
 		if err != nil {
-			return fmt.Errorf("failed to marshal request body: %w", err)
+			var syntaxErr *json.SyntaxError
+			return !errors.As(err, &syntaxErr)
 		}
-		reqBody = bytes.NewReader(jsonData)
+	}
+}
+
+// isJSONContentType reports whether contentType is application/json or a
+// +json media type
+func isJSONContentType(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	return err == nil && (mediaType == "application/json" || strings.HasSuffix(mediaType, "+json"))
+}
+
+// perform runs op through the middleware chain around final, adding the
+// call's headers and recording metrics, traces and response metadata
+func (c *Client) perform(ctx context.Context, op *Operation, final Handler) (*Response, error) {
+	for key, values := range callOptionsFromContext(ctx).header {
+		op.Header[key] = slices.Clone(values)
+	}
+	ctx, span := c.startOperationSpan(ctx, op)
+	start := time.Now()
+	resp, err := c.handler(final)(ctx, op)
+	duration := time.Since(start)
+	c.recordMetrics(ctx, op, resp, err, duration)
+	captureResponse(ctx, resp, err, duration)
+	endSpan(span, resp, err)
+	return resp, err
+}
+
+// transport returns the Handler that the middleware chain wraps: roundTrip
+// together with the client's built-in layers
+func (c *Client) transport() Handler {
+	h := c.liveTransport()
+	if c.dryRun != nil {
+		h = c.recordDryRun(h)
+	}
+	return h
+}
+
+// liveTransport returns the built-in layers that send requests to the API
+func (c *Client) liveTransport() Handler {
+	h := Handler(c.roundTrip)
+	if c.conditionalStore != nil {
+		h = c.revalidate(h)
+	}
+	if c.responseCache != nil {
+		h = c.responseCache.handler(h)
+	}
+	if c.coalescer != nil {
+		h = c.coalescer.handler(h)
+	}
+	return h
+}
+
+// roundTrip is the innermost Handler. It encodes the operation as an HTTP
+// request and sends it
+func (c *Client) roundTrip(ctx context.Context, op *Operation) (*Response, error) {
+	var bodyData []byte
+	if op.Body != nil {
+		jsonData, err := json.Marshal(op.Body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal request body: %w", err)
+		}
+		bodyData = jsonData
 	}
 
 	// Parse the path to handle query parameters correctly
-	u, err := url.Parse(path)
+	u, err := url.Parse(op.Path)
 	if err != nil {
-		return fmt.Errorf("failed to parse path: %w", err)
+		return nil, fmt.Errorf("failed to parse path: %w", err)
 	}
 
 	// Join with base URL
 	reqURL := c.baseURL.ResolveReference(u)
 
-	req, err := http.NewRequestWithContext(ctx, method, reqURL.String(), reqBody)
-	if err != nil {
-		return fmt.Errorf("failed to create request: %w", err)
+	return c.send(ctx, op, reqURL, bodyData)
+}
+
+// send performs the HTTP request, waiting for the client's rate limiter before
+// each attempt and retrying failed attempts according to the client's retry policy.
+// Attempts fail fast while the client's circuit breaker is open.
+// A request rejected with 401 is retried once if the token source can supply a new token
+func (c *Client) send(ctx context.Context, op *Operation, reqURL *url.URL, body []byte) (*Response, error) {
+	callOpts := callOptionsFromContext(ctx)
+	httpClient := c.httpClient
+	if callOpts.timeout != nil {
+		overridden := *c.httpClient
+		overridden.Timeout = max(*callOpts.timeout, 0)
+		httpClient = &overridden
 	}
 
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.apiKey))
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Accept", "application/json")
+	reauthenticated := false
+	for attempt := 0; ; attempt++ {
+		// An open circuit fails fast, before waiting for the rate limiter or a token
+		recordOutcome, err := c.allowRequest(reqURL.Host, op)
+		if err != nil {
+			return nil, err
+		}
 
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		return fmt.Errorf("failed to send request: %w", err)
+		if err := c.rateLimiter.wait(ctx, op.Method, reqURL.Path); err != nil {
+			recordOutcome(errNotSent)
+			return nil, err
+		}
+
+		// The body reader is recreated for every attempt
+		var reqBody io.Reader
+		contentType := "application/json"
+		if op.openBody != nil {
+			var err error
+			if reqBody, contentType, err = op.openBody(); err != nil {
+				recordOutcome(errNotSent)
+				return nil, err
+			}
+		} else if body != nil {
+			reqBody = bytes.NewReader(body)
+		}
+
+		attemptCtx, span := c.startAttemptSpan(ctx, op, attempt)
+		req, err := http.NewRequestWithContext(attemptCtx, op.Method, reqURL.String(), reqBody)
+		if err != nil {
+			err = fmt.Errorf("failed to create request: %w", err)
+			recordOutcome(errNotSent)
+			closeBody(reqBody)
+			span.End(err)
+			return nil, err
+		}
+
+		var token string
+		if !op.download {
+			if token, err = c.token(attemptCtx); err != nil {
+				recordOutcome(errNotSent)
+				closeBody(reqBody)
+				span.End(err)
+				return nil, err
+			}
+			req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+		}
+		req.Header.Set("Content-Type", contentType)
+		req.Header.Set("Accept", "application/json")
+		req.Header.Set("Accept-Encoding", "gzip")
+		injectTraceContext(attemptCtx, req)
+		for key, values := range op.Header {
+			req.Header[key] = values
+		}
+
+		c.logRequest(attemptCtx, op, req, body, attempt)
+
+		start := time.Now()
+		httpResp, response, streamed, err := sendAttempt(httpClient, req, op)
+		if response != nil {
+			response.attempts = attempt + 1
+		}
+		latency := time.Since(start)
+		c.logResponse(attemptCtx, op, response, err, attempt, latency)
+		c.writeDebug(op, req, body, response, err, attempt, latency)
+		recordOutcome(err)
+		endSpan(span, response, err)
+		if err == nil {
+			return response, nil
+		}
+
+		// Items of a streamed response may already have been delivered
+		if streamed {
+			return response, err
+		}
+
+		// Retry once with a fresh token when the token was rejected
+		if IsUnauthorized(err) && !op.download && !reauthenticated && c.invalidateToken(ctx, token) {
+			reauthenticated = true
+			continue
+		}
+
+		if callOpts.disableRetries {
+			return response, err
+		}
+		delay, retry := c.retryPolicy.retryDelay(op.Method, op.Header, attempt, httpResp, err)
+		if !retry || !sleep(ctx, delay) {
+			return response, err
+		}
+	}
+}
+
+// errHeaderTimeout is the cause of canceling a streamed request whose response
+// headers did not arrive within the timeout of the HTTP client
+var errHeaderTimeout = errors.New("timeout awaiting response headers")
+
+// sendAttempt sends req and reads its response, or streams the body of a
+// successful response if op has a stream. The timeout of httpClient only
+// covers the wait for the headers of a streamed response, so that the time
+// the caller takes to consume the body does not count against it
+func sendAttempt(httpClient *http.Client, req *http.Request, op *Operation) (*http.Response, *Response, bool, error) {
+	stopTimer := func() bool { return false }
+	if timeout := httpClient.Timeout; op.stream != nil && timeout > 0 {
+		ctx, cancel := context.WithCancelCause(req.Context())
+		defer cancel(nil)
+		timer := time.AfterFunc(timeout, func() {
+			cancel(fmt.Errorf("%w after %s", errHeaderTimeout, timeout))
+		})
+		stopTimer = timer.Stop
+		req = req.WithContext(ctx)
+
+		untimed := *httpClient
+		untimed.Timeout = 0
+		httpClient = &untimed
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode >= 400 {
-		var errResp ErrorResponse
-		if err := json.NewDecoder(resp.Body).Decode(&errResp); err != nil {
-			return fmt.Errorf("failed to decode error response: %w", err)
+	httpResp, err := httpClient.Do(req)
+	stopTimer()
+	switch {
+	case err != nil:
+		if cause := context.Cause(req.Context()); errors.Is(cause, errHeaderTimeout) {
+			err = cause
 		}
-		return fmt.Errorf("API error: %s (code: %s)", errResp.Message, errResp.Code)
+		return nil, nil, false, fmt.Errorf("failed to send request: %w", err)
+	case op.stream != nil && httpResp.StatusCode < 400:
+		response, err := streamResponse(httpResp, op)
+		return httpResp, response, true, err
 	}
+	response, err := readResponse(httpResp)
+	return httpResp, response, false, err
+}
+
+// closeBody closes a request body that was not sent, which stops the
+// goroutine writing a streamed body
+func closeBody(body io.Reader) {
+	if closer, ok := body.(io.Closer); ok {
+		closer.Close()
+	}
+}
 
-	if result != nil {
-		if err := json.NewDecoder(resp.Body).Decode(result); err != nil {
-			return fmt.Errorf("failed to decode response: %w", err)
+// readResponse reads and closes the response body. It returns an APIError
+// together with the response if the status code is not successful
+func readResponse(httpResp *http.Response) (*Response, error) {
+	defer httpResp.Body.Close()
+
+	if err := decompress(httpResp); err != nil {
+		return nil, err
+	}
+	respBody, err := io.ReadAll(httpResp.Body)
+	if err != nil {
+		if httpResp.StatusCode >= 400 {
+			return nil, fmt.Errorf("failed to read error response: %w", err)
 		}
+		return nil, fmt.Errorf("failed to read response: %w", err)
 	}
 
-	return nil
+	response := &Response{
+		StatusCode: httpResp.StatusCode,
+		Header:     httpResp.Header,
+		Body:       respBody,
+	}
+	if httpResp.StatusCode >= 400 {
+		return response, newAPIError(httpResp, respBody)
+	}
+	return response, nil
 }
 
 // ListAuditLogEventTypes lists all audit log event types
 func (c *Client) ListAuditLogEventTypes(ctx context.Context) (*ListResponse[AuditLogEventType], error) {
 	var result ListResponse[AuditLogEventType]
-	if err := c.doRequest(ctx, http.MethodGet, "/api/audit-log-event-types", nil, &result); err != nil {
+	if err := c.doRequest(ctx, "ListAuditLogEventTypes", http.MethodGet, "/api/audit-log-event-types", nil, &result); err != nil {
 		return nil, err
 	}
 	return &result, nil
@@ -132,7 +510,7 @@ func (c *Client) ListAuditLogEventTypes(ctx context.Context) (*ListResponse[Audi
 // GetAuditLogEventType gets a single audit log event type by ID
 func (c *Client) GetAuditLogEventType(ctx context.Context, id int) (*AuditLogEventType, error) {
 	var result AuditLogEventType
-	if err := c.doRequest(ctx, http.MethodGet, fmt.Sprintf("/api/audit-log-event-types/%d", id), nil, &result); err != nil {
+	if err := c.doRequest(ctx, "GetAuditLogEventType", http.MethodGet, fmt.Sprintf("/api/audit-log-event-types/%d", id), nil, &result); err != nil {
 		return nil, err
 	}
 	return &result, nil
@@ -3,6 +3,7 @@ package tcgcollector
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
@@ -156,7 +157,7 @@ func TestDoRequestErrors(t *testing.T) {
 	t.Run("invalid request body", func(t *testing.T) {
 		ctx := context.Background()
 		body := make(chan int) // channels cannot be marshaled to JSON
-		err := client.doRequest(ctx, http.MethodPost, "/test", body, nil)
+		err := client.doRequest(ctx, "Test", http.MethodPost, "/test", body, nil)
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "failed to marshal request body")
 	})
@@ -164,7 +165,7 @@ func TestDoRequestErrors(t *testing.T) {
 	// Test invalid path parsing
 	t.Run("invalid path", func(t *testing.T) {
 		ctx := context.Background()
-		err := client.doRequest(ctx, http.MethodGet, ":\\invalid", nil, nil)
+		err := client.doRequest(ctx, "Test", http.MethodGet, ":\\invalid", nil, nil)
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "failed to parse path")
 	})
@@ -172,7 +173,7 @@ func TestDoRequestErrors(t *testing.T) {
 	// Test request creation failure
 	t.Run("invalid method", func(t *testing.T) {
 		ctx := context.Background()
-		err := client.doRequest(ctx, "\n", "/test", nil, nil)
+		err := client.doRequest(ctx, "Test", "\n", "/test", nil, nil)
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "failed to create request")
 	})
@@ -183,13 +184,13 @@ func TestDoRequestErrors(t *testing.T) {
 			Transport: &errorRoundTripper{},
 		}))
 		ctx := context.Background()
-		err := client.doRequest(ctx, http.MethodGet, "/test", nil, nil)
+		err := client.doRequest(ctx, "Test", http.MethodGet, "/test", nil, nil)
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "failed to send request")
 	})
 
-	// Test error response decoding failure
-	t.Run("error response decode failure", func(t *testing.T) {
+	// Test non-JSON error response
+	t.Run("non-JSON error response", func(t *testing.T) {
 		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			w.WriteHeader(http.StatusBadRequest)
 			w.Write([]byte("invalid json"))
@@ -198,9 +199,9 @@ func TestDoRequestErrors(t *testing.T) {
 
 		client := NewClient("test-api-key", WithBaseURL(ts.URL))
 		ctx := context.Background()
-		err := client.doRequest(ctx, http.MethodGet, "/test", nil, nil)
+		err := client.doRequest(ctx, "Test", http.MethodGet, "/test", nil, nil)
 		assert.Error(t, err)
-		assert.Contains(t, err.Error(), "failed to decode error response")
+		assert.Contains(t, err.Error(), "API error: 400 Bad Request: invalid json")
 	})
 
 	// Test response decoding failure
@@ -214,7 +215,7 @@ func TestDoRequestErrors(t *testing.T) {
 		client := NewClient("test-api-key", WithBaseURL(ts.URL))
 		ctx := context.Background()
 		var result struct{ Field string }
-		err := client.doRequest(ctx, http.MethodGet, "/test", nil, &result)
+		err := client.doRequest(ctx, "Test", http.MethodGet, "/test", nil, &result)
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "failed to decode response")
 	})
@@ -260,7 +261,7 @@ func TestDoRequestContextCancellation(t *testing.T) {
 	// Cancel the context immediately
 	cancel()
 
-	err := client.doRequest(ctx, http.MethodGet, "/test", nil, nil)
+	err := client.doRequest(ctx, "Test", http.MethodGet, "/test", nil, nil)
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "context canceled")
 }
@@ -276,7 +277,7 @@ func TestDoRequestContextTimeout(t *testing.T) {
 	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Millisecond)
 	defer cancel()
 
-	err := client.doRequest(ctx, http.MethodGet, "/test", nil, nil)
+	err := client.doRequest(ctx, "Test", http.MethodGet, "/test", nil, nil)
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "context deadline exceeded")
 }
@@ -293,14 +294,14 @@ func TestClientWithCustomTimeout(t *testing.T) {
 func TestClientWithInvalidRequestBody(t *testing.T) {
 	client := NewClient("test-api-key")
 	invalidBody := make(chan int)
-	err := client.doRequest(context.Background(), http.MethodPost, "/test", invalidBody, nil)
+	err := client.doRequest(context.Background(), "Test", http.MethodPost, "/test", invalidBody, nil)
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "failed to marshal request body")
 }
 
 func TestClientWithInvalidPath(t *testing.T) {
 	client := NewClient("test-api-key")
-	err := client.doRequest(context.Background(), http.MethodGet, ":\\invalid", nil, nil)
+	err := client.doRequest(context.Background(), "Test", http.MethodGet, ":\\invalid", nil, nil)
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "failed to parse path")
 }
@@ -313,7 +314,7 @@ func TestClientWithInvalidMethod(t *testing.T) {
 	defer server.Close()
 
 	client := NewClient("test-api-key", WithBaseURL(server.URL))
-	err := client.doRequest(context.Background(), "INVALID", "/test", nil, nil)
+	err := client.doRequest(context.Background(), "Test", "INVALID", "/test", nil, nil)
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "API error: Invalid method")
 }
@@ -326,7 +327,7 @@ func TestClientWithEmptyAPIKey(t *testing.T) {
 	defer server.Close()
 
 	client := NewClient("", WithBaseURL(server.URL))
-	err := client.doRequest(context.Background(), http.MethodGet, "/test", nil, nil)
+	err := client.doRequest(context.Background(), "Test", http.MethodGet, "/test", nil, nil)
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "API error: API key is required")
 }
@@ -339,7 +340,7 @@ func TestClientWithNilResponse(t *testing.T) {
 	defer server.Close()
 
 	client := NewClient("test-api-key", WithBaseURL(server.URL))
-	err := client.doRequest(context.Background(), http.MethodGet, "/test", nil, nil)
+	err := client.doRequest(context.Background(), "Test", http.MethodGet, "/test", nil, nil)
 	assert.NoError(t, err)
 }
 
@@ -382,11 +383,107 @@ func TestClientWithInvalidResponse(t *testing.T) {
 
 	client := NewClient("test-api-key", WithBaseURL(server.URL))
 	var result struct{ Field string }
-	err := client.doRequest(context.Background(), http.MethodGet, "/test", nil, &result)
+	err := client.doRequest(context.Background(), "Test", http.MethodGet, "/test", nil, &result)
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "failed to decode response")
 }
 
+func TestClientWithEmptyResponses(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		status int
+		body   string
+	}{
+		{"no content", http.StatusNoContent, ""},
+		{"reset content", http.StatusResetContent, ""},
+		{"empty ok", http.StatusOK, ""},
+		{"whitespace created", http.StatusCreated, " \n"},
+		{"empty accepted", http.StatusAccepted, ""},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tc.status)
+				w.Write([]byte(tc.body))
+			}))
+			defer server.Close()
+
+			client := NewClient("test-api-key", WithBaseURL(server.URL))
+			for _, method := range []string{http.MethodGet, http.MethodPost, http.MethodPut, http.MethodDelete} {
+				result := struct{ Field string }{Field: "unchanged"}
+				err := client.doRequest(context.Background(), "Test", method, "/test", nil, &result)
+				assert.NoError(t, err, method)
+				assert.Equal(t, "unchanged", result.Field, method)
+			}
+		})
+	}
+}
+
+func TestClientWithNonJSONResponse(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`<html><body>Maintenance</body></html>`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-api-key", WithBaseURL(server.URL))
+	var result struct{ Field string }
+	err := client.doRequest(context.Background(), "Test", http.MethodGet, "/test", nil, &result)
+	assert.ErrorIs(t, err, ErrNotJSON)
+	assert.EqualError(t, err, `failed to decode response: response is not JSON (status 200, Content-Type "text/html"): <html><body>Maintenance</body></html>`)
+
+	var items []error
+	for _, err := range client.StreamSetCards(context.Background(), 1) {
+		items = append(items, err)
+	}
+	if assert.Len(t, items, 1) {
+		assert.ErrorIs(t, items[0], ErrNotJSON)
+	}
+}
+
+func TestClientWithPlainTextResponse(t *testing.T) {
+	tests := []struct {
+		contentType string
+		body        string
+		notJSON     bool
+	}{
+		{"text/plain", "forbidden", true},
+		{"text/plain", "not here", true},
+		{"text/plain", "true story", true},
+		{"text/plain; charset=utf-8", "404 page not found", true},
+		{"", "404 page not found", true},
+		{"text/html", `{"field": "html"}`, true},
+		// Servers often send JSON without declaring it
+		{"text/plain; charset=utf-8", `{"field": "plain"}`, false},
+		{"", `{"field": "missing"}`, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.contentType+" "+tt.body, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				// A nil Content-Type stops the server from detecting one
+				w.Header()["Content-Type"] = nil
+				if tt.contentType != "" {
+					w.Header().Set("Content-Type", tt.contentType)
+				}
+				w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			client := NewClient("test-api-key", WithBaseURL(server.URL))
+			var result struct{ Field string }
+			err := client.doRequest(context.Background(), "Test", http.MethodGet, "/test", nil, &result)
+			if tt.notJSON {
+				assert.ErrorIs(t, err, ErrNotJSON)
+				assert.ErrorContains(t, err, tt.body)
+			} else {
+				assert.NoError(t, err)
+				assert.NotEmpty(t, result.Field)
+			}
+		})
+	}
+}
+
 func TestClientWithInvalidErrorResponse(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Content-Type", "application/json")
@@ -396,14 +493,18 @@ func TestClientWithInvalidErrorResponse(t *testing.T) {
 	defer server.Close()
 
 	client := NewClient("test-api-key", WithBaseURL(server.URL))
-	err := client.doRequest(context.Background(), http.MethodGet, "/test", nil, nil)
+	err := client.doRequest(context.Background(), "Test", http.MethodGet, "/test", nil, nil)
 	assert.Error(t, err)
-	assert.Contains(t, err.Error(), "failed to decode error response")
+	assert.Contains(t, err.Error(), "API error: 400 Bad Request")
+
+	var apiErr *APIError
+	assert.True(t, errors.As(err, &apiErr))
+	assert.Equal(t, `{"invalid json`, string(apiErr.Body))
 }
 
 func TestClientWithRequestCreationError(t *testing.T) {
 	client := NewClient("test-api-key")
-	err := client.doRequest(context.Background(), string([]byte{0x7f}), "/test", nil, nil)
+	err := client.doRequest(context.Background(), "Test", string([]byte{0x7f}), "/test", nil, nil)
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "failed to create request")
 }
@@ -412,7 +513,7 @@ func TestClientWithHTTPError(t *testing.T) {
 	client := NewClient("test-api-key", WithHTTPClient(&http.Client{
 		Transport: &errorRoundTripper{},
 	}))
-	err := client.doRequest(context.Background(), http.MethodGet, "/test", nil, nil)
+	err := client.doRequest(context.Background(), "Test", http.MethodGet, "/test", nil, nil)
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "failed to send request")
 }
@@ -430,7 +531,7 @@ func TestClientWithRelativeURL(t *testing.T) {
 	defer server.Close()
 
 	client := NewClient("test-api-key", WithBaseURL(server.URL))
-	err := client.doRequest(context.Background(), http.MethodGet, "/api/test", nil, nil)
+	err := client.doRequest(context.Background(), "Test", http.MethodGet, "/api/test", nil, nil)
 	assert.NoError(t, err)
 }
 
@@ -442,7 +543,7 @@ func TestClientWithQueryParameters(t *testing.T) {
 	defer server.Close()
 
 	client := NewClient("test-api-key", WithBaseURL(server.URL))
-	err := client.doRequest(context.Background(), http.MethodGet, "/test?param=value", nil, nil)
+	err := client.doRequest(context.Background(), "Test", http.MethodGet, "/test?param=value", nil, nil)
 	assert.NoError(t, err)
 }
 
@@ -456,7 +557,7 @@ func TestClientWithHeaders(t *testing.T) {
 	defer server.Close()
 
 	client := NewClient("test-api-key", WithBaseURL(server.URL))
-	err := client.doRequest(context.Background(), http.MethodGet, "/test", nil, nil)
+	err := client.doRequest(context.Background(), "Test", http.MethodGet, "/test", nil, nil)
 	assert.NoError(t, err)
 }
 
@@ -472,7 +573,7 @@ func TestClientWithRequestBody(t *testing.T) {
 	defer server.Close()
 
 	client := NewClient("test-api-key", WithBaseURL(server.URL))
-	err := client.doRequest(context.Background(), http.MethodPost, "/test", expectedBody, nil)
+	err := client.doRequest(context.Background(), "Test", http.MethodPost, "/test", expectedBody, nil)
 	assert.NoError(t, err)
 }
 
@@ -487,7 +588,7 @@ func TestClientWithResponseBody(t *testing.T) {
 
 	client := NewClient("test-api-key", WithBaseURL(server.URL))
 	var response map[string]string
-	err := client.doRequest(context.Background(), http.MethodGet, "/test", nil, &response)
+	err := client.doRequest(context.Background(), "Test", http.MethodGet, "/test", nil, &response)
 	assert.NoError(t, err)
 	assert.Equal(t, expectedResponse, response)
 }
@@ -508,3 +609,81 @@ func TestClientWithInvalidBaseURLScheme(t *testing.T) {
 		NewClient("test-api-key", WithBaseURL("invalid://example.com"))
 	})
 }
+
+func TestNewClientWithOptions(t *testing.T) {
+	client, err := NewClientWithOptions("test-api-key",
+		WithBaseURL("http://localhost:8080"),
+		WithHTTPClient(&http.Client{Timeout: 5 * time.Second}),
+		WithRetryPolicy(DefaultRetryPolicy()),
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, "http://localhost:8080", client.baseURL.String())
+	assert.Equal(t, 5*time.Second, client.httpClient.Timeout)
+}
+
+func TestNewClientWithOptionsDefaults(t *testing.T) {
+	client, err := NewClientWithOptions("test-api-key")
+	assert.NoError(t, err)
+	assert.Equal(t, defaultBaseURL, client.baseURL.String())
+	assert.Equal(t, defaultTimeout, client.httpClient.Timeout)
+}
+
+func TestNewClientWithOptionsInvalidBaseURL(t *testing.T) {
+	tests := []struct {
+		name    string
+		baseURL string
+		wantErr string
+	}{
+		{"empty", "", "base URL cannot be empty"},
+		{"parse error", ":\\invalid", "invalid base URL"},
+		{"invalid scheme", "invalid://example.com", "invalid base URL scheme: invalid (must be http or https)"},
+		{"missing host", "http://", "must include a host"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.NotPanics(t, func() {
+				client, err := NewClientWithOptions("test-api-key", WithBaseURL(tt.baseURL))
+				assert.Nil(t, client)
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+			})
+		})
+	}
+}
+
+func TestNewClientWithOptionsReportsAllErrors(t *testing.T) {
+	client, err := NewClientWithOptions("test-api-key\n",
+		WithBaseURL("ftp://example.com"),
+		WithHTTPClient(&http.Client{Timeout: -time.Second}),
+		WithRetryPolicy(&RetryPolicy{MaxRetries: -1, BaseDelay: time.Second, MaxDelay: time.Millisecond}),
+	)
+	assert.Nil(t, client)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid client configuration")
+	assert.Contains(t, err.Error(), "invalid base URL scheme: ftp")
+	assert.Contains(t, err.Error(), "invalid timeout: -1s")
+	assert.Contains(t, err.Error(), "max retries -1 cannot be negative")
+	assert.Contains(t, err.Error(), "max delay 1ms is less than base delay 1s")
+	assert.Contains(t, err.Error(), "invalid API key: contains leading or trailing whitespace")
+}
+
+func TestNewClientWithOptionsNilHTTPClient(t *testing.T) {
+	_, err := NewClientWithOptions("test-api-key", WithHTTPClient(nil))
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "HTTP client cannot be nil")
+}
+
+func TestNewClientWithOptionsAPIKey(t *testing.T) {
+	_, err := NewClientWithOptions("test\x00key")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid API key: contains control characters")
+
+	// The API key is ignored when a token source is configured
+	_, err = NewClientWithOptions(" ", WithTokenSource(StaticTokenSource("token")))
+	assert.NoError(t, err)
+
+	// An empty API key is allowed for unauthenticated requests
+	_, err = NewClientWithOptions("")
+	assert.NoError(t, err)
+}
@@ -0,0 +1,115 @@
+package tcgcollector
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+)
+
+// WithRequestCoalescing shares a single HTTP round trip between identical GET
+// requests that are in flight at the same time. Each caller receives its own
+// copy of the response, and a caller whose context is canceled stops waiting
+// without aborting the request for the others
+func WithRequestCoalescing() ClientOption {
+	return func(c *Client) {
+		c.coalescer = &coalescer{calls: make(map[string]*coalescedCall)}
+	}
+}
+
+// coalescer de-duplicates identical in-flight GET requests
+type coalescer struct {
+	mu    sync.Mutex
+	calls map[string]*coalescedCall
+}
+
+// coalescedCall is a request shared by one or more callers
+type coalescedCall struct {
+	done    chan struct{}
+	resp    *Response
+	err     error
+	cancel  context.CancelFunc
+	waiters int
+}
+
+// handler returns a Handler that coalesces identical GET requests
+func (co *coalescer) handler(next Handler) Handler {
+	return func(ctx context.Context, op *Operation) (*Response, error) {
+		if op.Method != http.MethodGet {
+			return next(ctx, op)
+		}
+
+		key := coalesceKey(ctx, op)
+		co.mu.Lock()
+		call, ok := co.calls[key]
+		if !ok {
+			// The shared request is only canceled once every caller has stopped waiting
+			sharedCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+			call = &coalescedCall{done: make(chan struct{}), cancel: cancel}
+			co.calls[key] = call
+			go co.do(sharedCtx, key, call, op, next)
+		}
+		call.waiters++
+		co.mu.Unlock()
+
+		select {
+		case <-call.done:
+			return call.resp.clone(), call.err
+		case <-ctx.Done():
+			co.mu.Lock()
+			call.waiters--
+			if call.waiters == 0 {
+				call.cancel()
+				if co.calls[key] == call {
+					delete(co.calls, key)
+				}
+			}
+			co.mu.Unlock()
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// coalesceKey identifies the GET requests for op that can share a round trip.
+// The shared request is sent with the call options of the first caller, so
+// the options that change how it is sent must match as well
+func coalesceKey(ctx context.Context, op *Operation) string {
+	key := requestKey(ctx, op)
+	o := callOptionsFromContext(ctx)
+	if o.timeout == nil && !o.disableRetries && !o.disableCache {
+		return key
+	}
+
+	timeout := "default"
+	if o.timeout != nil {
+		timeout = o.timeout.String()
+	}
+	return fmt.Sprintf("timeout=%s retries=%t cache=%t %s", timeout, !o.disableRetries, !o.disableCache, key)
+}
+
+// do performs the shared request and releases its callers
+func (co *coalescer) do(ctx context.Context, key string, call *coalescedCall, op *Operation, next Handler) {
+	defer call.cancel()
+	call.resp, call.err = next(ctx, op)
+
+	co.mu.Lock()
+	if co.calls[key] == call {
+		delete(co.calls, key)
+	}
+	co.mu.Unlock()
+	close(call.done)
+}
+
+// clone returns a copy of the response that its receiver can modify
+func (r *Response) clone() *Response {
+	if r == nil {
+		return nil
+	}
+	return &Response{
+		StatusCode: r.StatusCode,
+		Header:     r.Header.Clone(),
+		Body:       bytes.Clone(r.Body),
+		attempts:   r.attempts,
+	}
+}
@@ -0,0 +1,191 @@
+package tcgcollector
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// newBlockingServer returns a test server whose responses are held until
+// release is closed. canceled is closed when a request's context is canceled
+func newBlockingServer(t *testing.T) (ts *httptest.Server, requests *int32, release, canceled chan struct{}) {
+	requests = new(int32)
+	release = make(chan struct{})
+	canceled = make(chan struct{})
+	var once sync.Once
+	ts = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(requests, 1)
+		select {
+		case <-release:
+			w.Write([]byte(`{"id": 1, "name": "Pikachu"}`))
+		case <-r.Context().Done():
+			once.Do(func() { close(canceled) })
+		}
+	}))
+	return ts, requests, release, canceled
+}
+
+// waitForWaiters waits until n callers are waiting for the request for path
+func waitForWaiters(t *testing.T, client *Client, path string, n int) {
+	assert.Eventually(t, func() bool {
+		client.coalescer.mu.Lock()
+		defer client.coalescer.mu.Unlock()
+		call, ok := client.coalescer.calls[path]
+		return ok && call.waiters == n
+	}, time.Second, time.Millisecond)
+}
+
+func TestRequestCoalescing(t *testing.T) {
+	ts, requests, release, _ := newBlockingServer(t)
+	defer ts.Close()
+
+	client := NewClient("test-api-key", WithBaseURL(ts.URL), WithRequestCoalescing())
+
+	const callers = 10
+	cards := make([]*Card, callers)
+	var wg sync.WaitGroup
+	for i := range cards {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			card, err := client.GetCard(context.Background(), 1)
+			assert.NoError(t, err)
+			cards[i] = card
+		}()
+	}
+
+	waitForWaiters(t, client, "/api/cards/1", callers)
+	close(release)
+	wg.Wait()
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(requests))
+	for _, card := range cards {
+		assert.Equal(t, "Pikachu", card.Name)
+	}
+
+	// Every caller receives its own copy
+	cards[0].Name = "Raichu"
+	assert.Equal(t, "Pikachu", cards[1].Name)
+
+	// Requests made after the shared one completes are sent again
+	_, err := client.GetCard(context.Background(), 1)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(2), atomic.LoadInt32(requests))
+}
+
+func TestRequestCoalescingCallerCancellation(t *testing.T) {
+	ts, requests, release, canceled := newBlockingServer(t)
+	defer ts.Close()
+
+	client := NewClient("test-api-key", WithBaseURL(ts.URL), WithRequestCoalescing())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	errs := make(chan error, 2)
+	go func() {
+		_, err := client.GetCard(ctx, 1)
+		errs <- err
+	}()
+	go func() {
+		card, err := client.GetCard(context.Background(), 1)
+		if err == nil {
+			assert.Equal(t, "Pikachu", card.Name)
+		}
+		errs <- err
+	}()
+
+	waitForWaiters(t, client, "/api/cards/1", 2)
+	cancel()
+	assert.ErrorIs(t, <-errs, context.Canceled)
+
+	// The other caller still receives the response
+	close(release)
+	assert.NoError(t, <-errs)
+	assert.Equal(t, int32(1), atomic.LoadInt32(requests))
+	select {
+	case <-canceled:
+		t.Error("Expected shared request not to be canceled")
+	default:
+	}
+}
+
+func TestRequestCoalescingSeparatesCallOptions(t *testing.T) {
+	ts, requests, release, canceled := newBlockingServer(t)
+	defer ts.Close()
+
+	client := NewClient("test-api-key", WithBaseURL(ts.URL), WithRequestCoalescing())
+
+	timed := WithCallOptions(context.Background(), Timeout(50*time.Millisecond))
+	errs := make(chan error, 2)
+	go func() {
+		_, err := client.GetCard(timed, 1)
+		errs <- err
+	}()
+	waitForWaiters(t, client, coalesceKey(timed, newOperation("GetCard", http.MethodGet, "/api/cards/1", nil)), 1)
+
+	// A caller without the timeout does not join the request that times out
+	go func() {
+		_, err := client.GetCard(context.Background(), 1)
+		errs <- err
+	}()
+	waitForWaiters(t, client, "/api/cards/1", 1)
+	assert.Eventually(t, func() bool { return atomic.LoadInt32(requests) == 2 }, time.Second, time.Millisecond)
+
+	assert.ErrorContains(t, <-errs, "Client.Timeout exceeded")
+	<-canceled
+	close(release)
+	assert.NoError(t, <-errs)
+}
+
+func TestRequestCoalescingCancelsAbandonedRequest(t *testing.T) {
+	ts, _, release, canceled := newBlockingServer(t)
+	defer ts.Close()
+	defer close(release)
+
+	client := NewClient("test-api-key", WithBaseURL(ts.URL), WithRequestCoalescing())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	errs := make(chan error, 1)
+	go func() {
+		_, err := client.GetCard(ctx, 1)
+		errs <- err
+	}()
+
+	waitForWaiters(t, client, "/api/cards/1", 1)
+	cancel()
+	assert.ErrorIs(t, <-errs, context.Canceled)
+
+	select {
+	case <-canceled:
+	case <-time.After(time.Second):
+		t.Error("Expected request without waiting callers to be canceled")
+	}
+}
+
+func TestRequestCoalescingSkipsMutatingRequests(t *testing.T) {
+	var requests int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		time.Sleep(20 * time.Millisecond)
+		w.Write([]byte(`{"id": 1}`))
+	}))
+	defer ts.Close()
+
+	client := NewClient("test-api-key", WithBaseURL(ts.URL), WithRequestCoalescing())
+	var wg sync.WaitGroup
+	for i := 0; i < 3; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := client.CreateCollection(context.Background(), &Collection{Name: "Binder"})
+			assert.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, int32(3), atomic.LoadInt32(&requests))
+}
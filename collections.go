@@ -3,6 +3,7 @@ package tcgcollector
 import (
 	"context"
 	"fmt"
+	"iter"
 	"net/http"
 	"net/url"
 )
@@ -43,16 +44,29 @@ func (c *Client) ListCollections(ctx context.Context, params *ListCollectionsPar
 	}
 
 	var result ListResponse[Collection]
-	if err := c.doRequest(ctx, http.MethodGet, path, nil, &result); err != nil {
+	if err := c.doRequest(ctx, "ListCollections", http.MethodGet, path, nil, &result); err != nil {
 		return nil, err
 	}
 	return &result, nil
 }
 
+// AllCollections iterates over all collections matching params, fetching pages as needed
+func (c *Client) AllCollections(ctx context.Context, params *ListCollectionsParams, opts ...PageOption) iter.Seq2[Collection, error] {
+	var p ListCollectionsParams
+	if params != nil {
+		p = *params
+	}
+	return paginate(ctx, p.Page, func(ctx context.Context, page int) (Page[Collection], error) {
+		pageParams := p
+		pageParams.Page = &page
+		return c.ListCollections(ctx, &pageParams)
+	}, opts...)
+}
+
 // GetCollection gets a single collection by ID
 func (c *Client) GetCollection(ctx context.Context, id int) (*Collection, error) {
 	var result Collection
-	if err := c.doRequest(ctx, http.MethodGet, fmt.Sprintf("/api/collections/%d", id), nil, &result); err != nil {
+	if err := c.doRequest(ctx, "GetCollection", http.MethodGet, fmt.Sprintf("/api/collections/%d", id), nil, &result); err != nil {
 		return nil, err
 	}
 	return &result, nil
@@ -61,7 +75,7 @@ func (c *Client) GetCollection(ctx context.Context, id int) (*Collection, error)
 // CreateCollection creates a new collection
 func (c *Client) CreateCollection(ctx context.Context, collection *Collection) (*Collection, error) {
 	var result Collection
-	if err := c.doRequest(ctx, http.MethodPost, "/api/collections", collection, &result); err != nil {
+	if err := c.doIdempotentRequest(ctx, "CreateCollection", http.MethodPost, "/api/collections", collection, &result); err != nil {
 		return nil, err
 	}
 	return &result, nil
@@ -70,7 +84,7 @@ func (c *Client) CreateCollection(ctx context.Context, collection *Collection) (
 // UpdateCollection updates an existing collection
 func (c *Client) UpdateCollection(ctx context.Context, id int, collection *Collection) (*Collection, error) {
 	var result Collection
-	if err := c.doRequest(ctx, http.MethodPut, fmt.Sprintf("/api/collections/%d", id), collection, &result); err != nil {
+	if err := c.doRequest(ctx, "UpdateCollection", http.MethodPut, fmt.Sprintf("/api/collections/%d", id), collection, &result); err != nil {
 		return nil, err
 	}
 	return &result, nil
@@ -78,13 +92,13 @@ func (c *Client) UpdateCollection(ctx context.Context, id int, collection *Colle
 
 // DeleteCollection deletes a collection
 func (c *Client) DeleteCollection(ctx context.Context, id int) error {
-	return c.doRequest(ctx, http.MethodDelete, fmt.Sprintf("/api/collections/%d", id), nil, nil)
+	return c.doRequest(ctx, "DeleteCollection", http.MethodDelete, fmt.Sprintf("/api/collections/%d", id), nil, nil)
 }
 
 // ListCollectionCards lists all cards in a collection
 func (c *Client) ListCollectionCards(ctx context.Context, collectionID int) (*ListResponse[CollectionCard], error) {
 	var result ListResponse[CollectionCard]
-	if err := c.doRequest(ctx, http.MethodGet, fmt.Sprintf("/api/collections/%d/cards", collectionID), nil, &result); err != nil {
+	if err := c.doRequest(ctx, "ListCollectionCards", http.MethodGet, fmt.Sprintf("/api/collections/%d/cards", collectionID), nil, &result); err != nil {
 		return nil, err
 	}
 	return &result, nil
@@ -93,7 +107,7 @@ func (c *Client) ListCollectionCards(ctx context.Context, collectionID int) (*Li
 // AddCardToCollection adds a card to a collection
 func (c *Client) AddCardToCollection(ctx context.Context, collectionID int, card *CollectionCard) (*CollectionCard, error) {
 	var result CollectionCard
-	if err := c.doRequest(ctx, http.MethodPost, fmt.Sprintf("/api/collections/%d/cards", collectionID), card, &result); err != nil {
+	if err := c.doIdempotentRequest(ctx, "AddCardToCollection", http.MethodPost, fmt.Sprintf("/api/collections/%d/cards", collectionID), card, &result); err != nil {
 		return nil, err
 	}
 	return &result, nil
@@ -102,7 +116,7 @@ func (c *Client) AddCardToCollection(ctx context.Context, collectionID int, card
 // UpdateCollectionCard updates a card in a collection
 func (c *Client) UpdateCollectionCard(ctx context.Context, collectionID, cardID int, card *CollectionCard) (*CollectionCard, error) {
 	var result CollectionCard
-	if err := c.doRequest(ctx, http.MethodPut, fmt.Sprintf("/api/collections/%d/cards/%d", collectionID, cardID), card, &result); err != nil {
+	if err := c.doRequest(ctx, "UpdateCollectionCard", http.MethodPut, fmt.Sprintf("/api/collections/%d/cards/%d", collectionID, cardID), card, &result); err != nil {
 		return nil, err
 	}
 	return &result, nil
@@ -110,15 +124,15 @@ func (c *Client) UpdateCollectionCard(ctx context.Context, collectionID, cardID
 
 // RemoveCardFromCollection removes a card from a collection
 func (c *Client) RemoveCardFromCollection(ctx context.Context, collectionID, cardID int) error {
-	return c.doRequest(ctx, http.MethodDelete, fmt.Sprintf("/api/collections/%d/cards/%d", collectionID, cardID), nil, nil)
+	return c.doRequest(ctx, "RemoveCardFromCollection", http.MethodDelete, fmt.Sprintf("/api/collections/%d/cards/%d", collectionID, cardID), nil, nil)
 }
 
 // InvalidateCardListCache invalidates the card list cache
 func (c *Client) InvalidateCardListCache(ctx context.Context) error {
-	return c.doRequest(ctx, http.MethodPost, "/api/card-collection/invalidate-card-list-cache", nil, nil)
+	return c.doRequest(ctx, "InvalidateCardListCache", http.MethodPost, "/api/card-collection/invalidate-card-list-cache", nil, nil)
 }
 
 // InvalidateExpansionCache invalidates the expansion cache
 func (c *Client) InvalidateExpansionCache(ctx context.Context) error {
-	return c.doRequest(ctx, http.MethodPost, "/api/card-collection/invalidate-expansion-cache", nil, nil)
+	return c.doRequest(ctx, "InvalidateExpansionCache", http.MethodPost, "/api/card-collection/invalidate-expansion-cache", nil, nil)
 }
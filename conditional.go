@@ -0,0 +1,77 @@
+package tcgcollector
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// WithConditionalRequests caches GET responses that carry an ETag or
+// Last-Modified header in store and revalidates them with If-None-Match and
+// If-Modified-Since. When the API responds with 304 Not Modified, the cached
+// response is returned instead
+func WithConditionalRequests(store CacheStore) ClientOption {
+	return func(c *Client) {
+		c.conditionalStore = store
+	}
+}
+
+// revalidate returns a Handler that makes GET requests conditional on the
+// response cached in the client's conditional store
+func (c *Client) revalidate(next Handler) Handler {
+	store := c.conditionalStore
+	return func(ctx context.Context, op *Operation) (*Response, error) {
+		// Callers that set their own validators handle 304 themselves
+		if op.Method != http.MethodGet || op.Header.Get("If-None-Match") != "" || op.Header.Get("If-Modified-Since") != "" ||
+			callOptionsFromContext(ctx).disableCache {
+			return next(ctx, op)
+		}
+
+		// Responses to calls with different tokens are validated separately
+		key := requestKey(ctx, op)
+		cached, ok := store.Get(key)
+		if ok {
+			if etag := cached.ETag(); etag != "" {
+				op.Header.Set("If-None-Match", etag)
+			}
+			if lastModified := cached.LastModified(); lastModified != "" {
+				op.Header.Set("If-Modified-Since", lastModified)
+			}
+		}
+
+		resp, err := next(ctx, op)
+		if err != nil {
+			return resp, err
+		}
+
+		if resp.StatusCode == http.StatusNotModified && ok {
+			// The 304 may carry updated validators and caching headers
+			header := cached.Header.Clone()
+			for name, values := range resp.Header {
+				header[name] = values
+			}
+			entry := &CacheEntry{
+				Path:       op.Path,
+				StatusCode: cached.StatusCode,
+				Header:     header,
+				Body:       cached.Body,
+				StoredAt:   time.Now(),
+			}
+			store.Set(key, entry)
+			cachedResp := entry.response()
+			cachedResp.attempts = resp.attempts
+			return cachedResp, nil
+		}
+
+		if resp.StatusCode == http.StatusOK && (resp.Header.Get("ETag") != "" || resp.Header.Get("Last-Modified") != "") {
+			store.Set(key, &CacheEntry{
+				Path:       op.Path,
+				StatusCode: resp.StatusCode,
+				Header:     resp.Header.Clone(),
+				Body:       resp.Body,
+				StoredAt:   time.Now(),
+			})
+		}
+		return resp, nil
+	}
+}
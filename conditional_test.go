@@ -0,0 +1,146 @@
+package tcgcollector
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConditionalRequestsWithETag(t *testing.T) {
+	var requests, notModified int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			atomic.AddInt32(&notModified, 1)
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		assert.Empty(t, r.Header.Get("If-None-Match"))
+		w.Header().Set("ETag", `"v1"`)
+		w.Write([]byte(`[{"id": 1, "name": "Common"}, {"id": 2, "name": "Rare"}]`))
+	}))
+	defer ts.Close()
+
+	client := NewClient("test-api-key", WithBaseURL(ts.URL), WithConditionalRequests(NewMemoryCache(10)))
+	for i := 0; i < 3; i++ {
+		rarities, err := client.ListCardRarities(context.Background())
+		assert.NoError(t, err)
+		assert.Len(t, rarities, 2)
+		assert.Equal(t, "Rare", rarities[1].Name)
+	}
+
+	assert.Equal(t, int32(3), atomic.LoadInt32(&requests))
+	assert.Equal(t, int32(2), atomic.LoadInt32(&notModified))
+}
+
+func TestConditionalRequestsSeparatesPerCallTokens(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		etag := `"` + r.Header.Get("Authorization") + `"`
+		if r.Header.Get("If-None-Match") == etag {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		assert.Empty(t, r.Header.Get("If-None-Match"))
+		w.Header().Set("ETag", etag)
+		fmt.Fprintf(w, `{"id": 1, "name": %q}`, r.Header.Get("Authorization"))
+	}))
+	defer ts.Close()
+
+	client := NewClient("test-api-key", WithBaseURL(ts.URL), WithConditionalRequests(NewMemoryCache(10)))
+	tenantA := WithCallOptions(context.Background(), BearerToken("tenant-a"))
+	tenantB := WithCallOptions(context.Background(), BearerToken("tenant-b"))
+	for _, ctx := range []context.Context{tenantA, tenantB, tenantA, tenantB} {
+		card, err := client.GetCard(ctx, 1)
+		assert.NoError(t, err)
+		assert.Equal(t, "Bearer "+callOptionsFromContext(ctx).token, card.Name)
+	}
+}
+
+func TestConditionalRequestsWithLastModified(t *testing.T) {
+	const lastModified = "Mon, 02 Jan 2006 15:04:05 GMT"
+	var notModified int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-Modified-Since") == lastModified {
+			atomic.AddInt32(&notModified, 1)
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("Last-Modified", lastModified)
+		w.Write([]byte(`[{"id": 1, "code": "EUR"}]`))
+	}))
+	defer ts.Close()
+
+	dir := t.TempDir()
+	store, err := NewDiskCache(dir)
+	assert.NoError(t, err)
+	_, err = NewClient("test-api-key", WithBaseURL(ts.URL), WithConditionalRequests(store)).ListCurrencies(context.Background())
+	assert.NoError(t, err)
+
+	// A new client revalidates the response cached on disk
+	store, err = NewDiskCache(dir)
+	assert.NoError(t, err)
+	currencies, err := NewClient("test-api-key", WithBaseURL(ts.URL), WithConditionalRequests(store)).ListCurrencies(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, "EUR", currencies[0].Code)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&notModified))
+}
+
+func TestConditionalRequestsUpdatesChangedResponse(t *testing.T) {
+	var version int32 = 1
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		etag := fmt.Sprintf(`"v%d"`, atomic.LoadInt32(&version))
+		if r.Header.Get("If-None-Match") == etag {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", etag)
+		if atomic.LoadInt32(&version) == 1 {
+			w.Write([]byte(`{"items": [{"id": 1}], "page": 1, "pageCount": 1}`))
+		} else {
+			w.Write([]byte(`{"items": [{"id": 1}, {"id": 2}], "page": 1, "pageCount": 1}`))
+		}
+	}))
+	defer ts.Close()
+
+	store := NewMemoryCache(10)
+	client := NewClient("test-api-key", WithBaseURL(ts.URL), WithConditionalRequests(store))
+	cards, err := client.GetSetCards(context.Background(), 1)
+	assert.NoError(t, err)
+	assert.Len(t, cards.Items, 1)
+
+	atomic.StoreInt32(&version, 2)
+	cards, err = client.GetSetCards(context.Background(), 1)
+	assert.NoError(t, err)
+	assert.Len(t, cards.Items, 2)
+
+	entry, ok := store.Get("/api/sets/1/cards")
+	assert.True(t, ok)
+	assert.Equal(t, `"v2"`, entry.ETag())
+}
+
+func TestConditionalRequestsSkipsOtherMethodsAndUncacheableResponses(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Empty(t, r.Header.Get("If-None-Match"))
+		if r.Method == http.MethodGet {
+			// Responses without validators are not cached
+			w.Write([]byte(`[]`))
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		w.Write([]byte(`{"id": 1}`))
+	}))
+	defer ts.Close()
+
+	store := NewMemoryCache(10)
+	client := NewClient("test-api-key", WithBaseURL(ts.URL), WithConditionalRequests(store))
+	_, err := client.ListCardRarities(context.Background())
+	assert.NoError(t, err)
+	_, err = client.CreateCollection(context.Background(), &Collection{Name: "Binder"})
+	assert.NoError(t, err)
+	assert.Equal(t, 0, store.Len())
+}
@@ -18,7 +18,7 @@ type BaseTCGCurrency struct {
 // GetAllowedExternalAccountHosts retrieves the list of allowed external account hosts
 func (c *Client) GetAllowedExternalAccountHosts(ctx context.Context) (*AllowedExternalAccountHosts, error) {
 	var response AllowedExternalAccountHosts
-	if err := c.doRequest(ctx, http.MethodGet, "/api/configuration/allowed-external-account-hosts", nil, &response); err != nil {
+	if err := c.doRequest(ctx, "GetAllowedExternalAccountHosts", http.MethodGet, "/api/configuration/allowed-external-account-hosts", nil, &response); err != nil {
 		return nil, err
 	}
 	return &response, nil
@@ -27,7 +27,7 @@ func (c *Client) GetAllowedExternalAccountHosts(ctx context.Context) (*AllowedEx
 // GetBaseTCGCurrency retrieves the base TCG currency
 func (c *Client) GetBaseTCGCurrency(ctx context.Context) (*BaseTCGCurrency, error) {
 	var response BaseTCGCurrency
-	if err := c.doRequest(ctx, http.MethodGet, "/api/configuration/base-tcg-currency", nil, &response); err != nil {
+	if err := c.doRequest(ctx, "GetBaseTCGCurrency", http.MethodGet, "/api/configuration/base-tcg-currency", nil, &response); err != nil {
 		return nil, err
 	}
 	return &response, nil
@@ -20,7 +20,7 @@ type Currency struct {
 // ListCurrencies retrieves a list of currencies
 func (c *Client) ListCurrencies(ctx context.Context) ([]Currency, error) {
 	var response []Currency
-	if err := c.doRequest(ctx, http.MethodGet, "/api/currencies", nil, &response); err != nil {
+	if err := c.doRequest(ctx, "ListCurrencies", http.MethodGet, "/api/currencies", nil, &response); err != nil {
 		return nil, err
 	}
 	return response, nil
@@ -29,7 +29,7 @@ func (c *Client) ListCurrencies(ctx context.Context) ([]Currency, error) {
 // GetCurrency retrieves a single currency by ID
 func (c *Client) GetCurrency(ctx context.Context, id int) (*Currency, error) {
 	var response Currency
-	if err := c.doRequest(ctx, http.MethodGet, fmt.Sprintf("/api/currencies/%d", id), nil, &response); err != nil {
+	if err := c.doRequest(ctx, "GetCurrency", http.MethodGet, fmt.Sprintf("/api/currencies/%d", id), nil, &response); err != nil {
 		return nil, err
 	}
 	return &response, nil
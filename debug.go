@@ -0,0 +1,177 @@
+package tcgcollector
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// debugTokenVariable is the shell variable that replaces the token in curl commands
+	debugTokenVariable = "TCGCOLLECTOR_TOKEN"
+	// maxDebugBodySize is the maximum number of response body bytes written by the debug writer
+	maxDebugBodySize = 2048
+)
+
+// debugWriter writes a reproduction of every request attempt
+type debugWriter struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+// WithDebugWriter writes every request attempt to w as an equivalent curl
+// command, followed by the attempt number, its duration and a trimmed dump
+// of the response. The token is replaced by $TCGCOLLECTOR_TOKEN and secret
+// JSON fields are redacted unless WithDebugToken is used
+func WithDebugWriter(w io.Writer) ClientOption {
+	return func(c *Client) {
+		c.debug = &debugWriter{w: w}
+	}
+}
+
+// WithDebugToken includes the bearer token and secret JSON fields in the
+// output of WithDebugWriter, so that curl commands can be run as they are.
+// Only use it when the output is not shared
+func WithDebugToken() ClientOption {
+	return func(c *Client) {
+		c.debugToken = true
+	}
+}
+
+// writeDebug writes a request attempt and its outcome to the client's debug writer
+func (c *Client) writeDebug(op *Operation, req *http.Request, body []byte, resp *Response, err error, attempt int, latency time.Duration) {
+	if c.debug == nil {
+		return
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "# %s attempt %d\n", op.Name, attempt+1)
+	b.WriteString(c.curlCommand(op, req, body))
+	fmt.Fprintf(&b, "# %s in %s\n", debugStatus(resp, err), latency.Round(time.Millisecond))
+	if resp != nil {
+		if err != nil && resp.StatusCode < 400 {
+			fmt.Fprintf(&b, "# error: %v\n", err)
+		}
+		for _, key := range slices.Sorted(maps.Keys(resp.Header)) {
+			value := strings.Join(resp.Header[key], ", ")
+			if !c.debugToken && slices.Contains(redactedHeaders, key) {
+				value = redacted
+			}
+			fmt.Fprintf(&b, "# %s: %s\n", key, value)
+		}
+		b.WriteString(c.debugBody(op, resp))
+	}
+	b.WriteString("\n")
+
+	c.debug.mu.Lock()
+	defer c.debug.mu.Unlock()
+	io.WriteString(c.debug.w, b.String())
+}
+
+// curlCommand returns a curl command line that sends req
+func (c *Client) curlCommand(op *Operation, req *http.Request, body []byte) string {
+	var b strings.Builder
+	b.WriteString("curl --compressed")
+	if req.Method != http.MethodGet {
+		fmt.Fprintf(&b, " -X %s", req.Method)
+	}
+	fmt.Fprintf(&b, " %s", shellQuote(req.URL.String()))
+
+	for _, key := range slices.Sorted(maps.Keys(req.Header)) {
+		// curl negotiates the encoding itself with --compressed
+		if key == "Accept-Encoding" {
+			continue
+		}
+		for _, value := range req.Header[key] {
+			header := key + ": " + value
+			switch {
+			case key == "Authorization" && !c.debugToken:
+				// A double-quoted variable lets the command run after exporting the token
+				fmt.Fprintf(&b, " \\\n  -H \"Authorization: Bearer $%s\"", debugTokenVariable)
+				continue
+			case key == "Cookie" && !c.debugToken:
+				header = key + ": " + redacted
+			}
+			fmt.Fprintf(&b, " \\\n  -H %s", shellQuote(header))
+		}
+	}
+
+	switch {
+	case op.openBody != nil:
+		b.WriteString(" \\\n  --data-binary @- # streamed body not shown")
+	case body != nil:
+		if !c.debugToken {
+			body = redactSecrets(body)
+		}
+		fmt.Fprintf(&b, " \\\n  --data-binary %s", shellQuote(string(body)))
+	}
+	b.WriteString("\n")
+	return b.String()
+}
+
+// debugBody returns the trimmed body of a response
+func (c *Client) debugBody(op *Operation, resp *Response) string {
+	switch {
+	case op.stream != nil && resp.StatusCode < 400:
+		return "# (body streamed)\n"
+	case len(resp.Body) == 0:
+		return ""
+	}
+
+	body := resp.Body
+	if !c.debugToken {
+		body = redactSecrets(body)
+	}
+	if len(body) > maxDebugBodySize {
+		return fmt.Sprintf("%s\n# ... (%d bytes total)\n", body[:maxDebugBodySize], len(resp.Body))
+	}
+	return string(body) + "\n"
+}
+
+// redactSecrets replaces the values of secret JSON fields in body. Bodies
+// without secrets are returned unchanged, so they are reproduced verbatim
+func redactSecrets(body []byte) []byte {
+	var value interface{}
+	if err := json.Unmarshal(body, &value); err != nil || !hasSecretField(value) {
+		return body
+	}
+	return redactJSON(body)
+}
+
+// hasSecretField reports whether a decoded JSON value contains a secret field
+func hasSecretField(value interface{}) bool {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, field := range v {
+			if isRedactedField(key) || hasSecretField(field) {
+				return true
+			}
+		}
+	case []interface{}:
+		for _, item := range v {
+			if hasSecretField(item) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// debugStatus describes the outcome of an attempt
+func debugStatus(resp *Response, err error) string {
+	if resp == nil {
+		return fmt.Sprintf("error: %v", err)
+	}
+	return fmt.Sprintf("%d %s", resp.StatusCode, http.StatusText(resp.StatusCode))
+}
+
+// shellQuote quotes s for a POSIX shell
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
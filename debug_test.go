@@ -0,0 +1,117 @@
+package tcgcollector
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDebugWriter(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-Request-Id", "req-1")
+		w.Write([]byte(`{"id": 1, "name": "Binder"}`))
+	}))
+	defer ts.Close()
+
+	var out bytes.Buffer
+	client := NewClient("secret-key", WithBaseURL(ts.URL), WithDebugWriter(&out))
+
+	_, err := client.UpdateCollection(context.Background(), 1, &Collection{Name: "It's mine"})
+	assert.NoError(t, err)
+
+	dump := out.String()
+	assert.NotContains(t, dump, "secret-key")
+	assert.Contains(t, dump, "# UpdateCollection attempt 1\n")
+	assert.Contains(t, dump, "curl --compressed -X PUT '"+ts.URL+"/api/collections/1'")
+	assert.Contains(t, dump, `-H "Authorization: Bearer $TCGCOLLECTOR_TOKEN"`)
+	assert.Contains(t, dump, `-H 'Content-Type: application/json'`)
+	assert.NotContains(t, dump, "Accept-Encoding")
+	assert.Contains(t, dump, `"name":"It'\''s mine"`)
+	assert.Contains(t, dump, "# 200 OK in ")
+	assert.Contains(t, dump, "# X-Request-Id: req-1\n")
+	assert.Contains(t, dump, `{"id": 1, "name": "Binder"}`)
+}
+
+func TestDebugWriterRedactsSecrets(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"token": "jwt-secret"}`))
+	}))
+	defer ts.Close()
+
+	var out bytes.Buffer
+	client := NewClient("", WithBaseURL(ts.URL), WithDebugWriter(&out))
+	_, err := client.Login(context.Background(), &LoginRequest{Username: "ash", Password: "pikachu"})
+	assert.NoError(t, err)
+
+	dump := out.String()
+	assert.NotContains(t, dump, "pikachu")
+	assert.NotContains(t, dump, "jwt-secret")
+	assert.Contains(t, dump, redacted)
+}
+
+func TestDebugWriterWithToken(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{}`))
+	}))
+	defer ts.Close()
+
+	var out bytes.Buffer
+	client := NewClient("secret-key", WithBaseURL(ts.URL), WithDebugWriter(&out), WithDebugToken())
+	err := client.doRequest(context.Background(), "Test", http.MethodGet, "/test?q=a%27b", nil, nil)
+	assert.NoError(t, err)
+
+	dump := out.String()
+	assert.Contains(t, dump, "curl --compressed '"+ts.URL+"/test?q=a%27b'")
+	assert.Contains(t, dump, `-H 'Authorization: Bearer secret-key'`)
+}
+
+func TestDebugWriterRetries(t *testing.T) {
+	var attempts int32
+	body := strings.Repeat("x", maxDebugBodySize+100)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&attempts, 1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			w.Write([]byte(body))
+			return
+		}
+		w.Write([]byte(`{"id": 1}`))
+	}))
+	defer ts.Close()
+
+	var out bytes.Buffer
+	client := NewClient("test-api-key", WithBaseURL(ts.URL), WithRetryPolicy(testRetryPolicy()), WithDebugWriter(&out))
+	_, err := client.GetCard(context.Background(), 1)
+	assert.NoError(t, err)
+
+	dump := out.String()
+	assert.Contains(t, dump, "# GetCard attempt 1\n")
+	assert.Contains(t, dump, "# 503 Service Unavailable in ")
+	assert.Contains(t, dump, "# ... (2148 bytes total)\n")
+	assert.NotContains(t, dump, body)
+	assert.Contains(t, dump, "# GetCard attempt 2\n")
+	assert.Contains(t, dump, "# 200 OK in ")
+}
+
+func TestDebugWriterNetworkError(t *testing.T) {
+	var out bytes.Buffer
+	client := NewClient("test-api-key",
+		WithHTTPClient(&http.Client{Transport: &countingRoundTripper{}}),
+		WithDebugWriter(&out),
+	)
+	err := client.doRequest(context.Background(), "Test", http.MethodDelete, "/test", nil, nil)
+	assert.Error(t, err)
+	assert.Contains(t, out.String(), "curl --compressed -X DELETE")
+	assert.Contains(t, out.String(), "# error: failed to send request")
+}
+
+func TestShellQuote(t *testing.T) {
+	assert.Equal(t, `'plain'`, shellQuote("plain"))
+	assert.Equal(t, `'it'\''s'`, shellQuote("it's"))
+}
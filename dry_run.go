@@ -0,0 +1,188 @@
+package tcgcollector
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// dryRunExempt are the operations that a dry-run client still sends because
+// they do not change data and later requests need their tokens
+var dryRunExempt = []string{"Login", "RefreshToken"}
+
+// PlannedRequest is a request recorded by a client in dry-run mode
+type PlannedRequest struct {
+	// Operation is the name of the client method, e.g. "DeleteUser"
+	Operation string `json:"operation"`
+	// Method is the HTTP method
+	Method string `json:"method"`
+	// Path is the request path including the query string
+	Path string `json:"path"`
+	// Body is the JSON encoded request body
+	Body json.RawMessage `json:"body,omitempty"`
+	// Header contains the additional headers of the request, e.g. its Idempotency-Key
+	Header http.Header `json:"header,omitempty"`
+	// BodyOmitted is set when the body was streamed, e.g. by UploadImage, and
+	// could not be recorded. Plans with such requests cannot be executed
+	BodyOmitted bool `json:"bodyOmitted,omitempty"`
+}
+
+// String returns the request as a single line for review. Secret fields of
+// the body, such as passwords, are redacted; the JSON encoding of the plan
+// keeps them so that it can be executed
+func (r PlannedRequest) String() string {
+	s := r.Method + " " + r.Path
+	switch {
+	case r.BodyOmitted:
+		s += " (body omitted)"
+	case len(r.Body) > 0:
+		s += " " + string(redactSecrets(r.Body))
+	}
+	return s
+}
+
+// Plan is the list of requests that a client created with WithDryRun did not
+// send. It is safe for concurrent use and can be saved and loaded as JSON
+type Plan struct {
+	mu       sync.Mutex
+	requests []PlannedRequest
+}
+
+// Requests returns the recorded requests in the order they were made
+func (p *Plan) Requests() []PlannedRequest {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]PlannedRequest(nil), p.requests...)
+}
+
+// Len returns the number of recorded requests
+func (p *Plan) Len() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return len(p.requests)
+}
+
+// String returns the numbered requests of the plan, one per line
+func (p *Plan) String() string {
+	var b strings.Builder
+	for i, r := range p.Requests() {
+		fmt.Fprintf(&b, "%d. %s: %s\n", i+1, r.Operation, r)
+	}
+	return b.String()
+}
+
+// MarshalJSON encodes the plan as an array of requests
+func (p *Plan) MarshalJSON() ([]byte, error) {
+	requests := p.Requests()
+	if requests == nil {
+		requests = []PlannedRequest{}
+	}
+	return json.Marshal(requests)
+}
+
+// UnmarshalJSON replaces the requests of the plan with an encoded array of requests
+func (p *Plan) UnmarshalJSON(data []byte) error {
+	var requests []PlannedRequest
+	if err := json.Unmarshal(data, &requests); err != nil {
+		return err
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.requests = requests
+	return nil
+}
+
+// add records a request
+func (p *Plan) add(r PlannedRequest) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.requests = append(p.requests, r)
+}
+
+// WithDryRun records requests that could change data into plan instead of
+// sending them. GET, HEAD and OPTIONS requests, Login and RefreshToken are
+// still sent. Recorded calls succeed as if the API had responded with 204 No
+// Content, so methods that return a result return its zero value
+func WithDryRun(plan *Plan) ClientOption {
+	return func(c *Client) {
+		if plan == nil {
+			c.configError(errors.New("invalid dry run: plan cannot be nil"))
+			return
+		}
+		c.dryRun = plan
+	}
+}
+
+// recordDryRun returns a Handler that records operations in the client's plan
+// instead of performing them
+func (c *Client) recordDryRun(next Handler) Handler {
+	return func(ctx context.Context, op *Operation) (*Response, error) {
+		switch op.Method {
+		case http.MethodGet, http.MethodHead, http.MethodOptions:
+			return next(ctx, op)
+		}
+		for _, name := range dryRunExempt {
+			if op.Name == name {
+				return next(ctx, op)
+			}
+		}
+
+		request := PlannedRequest{
+			Operation:   op.Name,
+			Method:      op.Method,
+			Path:        op.Path,
+			BodyOmitted: op.openBody != nil,
+		}
+		if len(op.Header) > 0 {
+			request.Header = op.Header.Clone()
+		}
+		if op.Body != nil {
+			body, err := json.Marshal(op.Body)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal request body: %w", err)
+			}
+			request.Body = body
+		}
+		c.dryRun.add(request)
+
+		return &Response{StatusCode: http.StatusNoContent, Header: make(http.Header)}, nil
+	}
+}
+
+// ExecutePlan sends the requests of plan in order, exactly as they were
+// recorded, even if the client is in dry-run mode. It stops at the first
+// request that fails
+func (c *Client) ExecutePlan(ctx context.Context, plan *Plan) error {
+	requests := plan.Requests()
+	for i, r := range requests {
+		if r.BodyOmitted {
+			return fmt.Errorf("cannot execute plan: request %d (%s) has no recorded body", i+1, r)
+		}
+	}
+
+	for i, r := range requests {
+		op := &Operation{
+			Name:         r.Operation,
+			Method:       r.Method,
+			PathTemplate: pathTemplate(r.Path),
+			Path:         r.Path,
+			Header:       r.Header.Clone(),
+		}
+		if op.Header == nil {
+			op.Header = make(http.Header)
+		}
+		// A raw message is encoded as it is, so the body is sent verbatim
+		if len(r.Body) > 0 {
+			op.Body = r.Body
+		}
+
+		if _, err := c.perform(ctx, op, c.liveTransport()); err != nil {
+			return fmt.Errorf("failed to execute request %d of %d (%s %s): %w", i+1, len(requests), r.Method, r.Path, err)
+		}
+	}
+	return nil
+}
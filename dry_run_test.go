@@ -0,0 +1,197 @@
+package tcgcollector
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// recordingServer records the requests it receives and responds with an empty JSON object
+type recordingServer struct {
+	*httptest.Server
+	mu       sync.Mutex
+	requests []string
+}
+
+func newRecordingServer() *recordingServer {
+	s := &recordingServer{}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		s.mu.Lock()
+		s.requests = append(s.requests, strings.TrimSpace(r.Method+" "+r.URL.RequestURI()+" "+string(body)))
+		s.mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id": 5, "token": "jwt"}`))
+	}))
+	return s
+}
+
+func (s *recordingServer) received() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.requests...)
+}
+
+func TestDryRun(t *testing.T) {
+	ts := newRecordingServer()
+	defer ts.Close()
+
+	var plan Plan
+	client := NewClient("test-api-key", WithBaseURL(ts.URL), WithDryRun(&plan))
+	ctx := context.Background()
+
+	card, err := client.GetCard(ctx, 5)
+	assert.NoError(t, err)
+	assert.Equal(t, 5, card.ID)
+
+	assert.NoError(t, client.RecalculateCachedValues(ctx))
+	assert.NoError(t, client.DeleteUser(ctx, 3))
+	assert.NoError(t, client.BulkReplaceCardListEntries(ctx, 2, []CardListEntry{{CardID: 7}}))
+	collection, err := client.CreateCollection(WithCallOptions(ctx, IdempotencyKey("key-1")), &Collection{Name: "Binder"})
+	assert.NoError(t, err)
+	assert.Equal(t, 0, collection.ID)
+
+	// Only the GET request reached the API
+	assert.Equal(t, []string{"GET /api/cards/5"}, ts.received())
+
+	requests := plan.Requests()
+	if assert.Len(t, requests, 4) {
+		assert.Equal(t, PlannedRequest{Operation: "RecalculateCachedValues", Method: http.MethodPost, Path: "/api/cards/recalculate-cached-values"}, requests[0])
+		assert.Equal(t, PlannedRequest{Operation: "DeleteUser", Method: http.MethodDelete, Path: "/api/users/3"}, requests[1])
+		assert.Equal(t, "BulkReplaceCardListEntries", requests[2].Operation)
+		assert.JSONEq(t, `[{"id": 0, "cardListId": 0, "cardId": 7, "quantity": 0, "createdAt": "", "updatedAt": ""}]`, string(requests[2].Body))
+		assert.Equal(t, "key-1", requests[3].Header.Get(IdempotencyKeyHeader))
+	}
+
+	lines := strings.Split(strings.TrimSpace(plan.String()), "\n")
+	if assert.Len(t, lines, 4) {
+		assert.Equal(t, "1. RecalculateCachedValues: POST /api/cards/recalculate-cached-values", lines[0])
+		assert.Equal(t, "2. DeleteUser: DELETE /api/users/3", lines[1])
+	}
+}
+
+func TestDryRunSendsLogin(t *testing.T) {
+	ts := newRecordingServer()
+	defer ts.Close()
+
+	var plan Plan
+	client := NewClient("", WithBaseURL(ts.URL), WithDryRun(&plan))
+	_, err := client.Login(context.Background(), &LoginRequest{Username: "ash", Password: "pikachu"})
+	assert.NoError(t, err)
+	assert.Len(t, ts.received(), 1)
+	assert.Zero(t, plan.Len())
+}
+
+func TestDryRunUpload(t *testing.T) {
+	ts := newRecordingServer()
+	defer ts.Close()
+
+	var plan Plan
+	client := NewClient("test-api-key", WithBaseURL(ts.URL), WithDryRun(&plan))
+	reader := strings.NewReader("image data")
+	_, err := client.UploadImage(context.Background(), reader, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, 10, reader.Len())
+	assert.Empty(t, ts.received())
+
+	requests := plan.Requests()
+	if assert.Len(t, requests, 1) {
+		assert.True(t, requests[0].BodyOmitted)
+	}
+	err = client.ExecutePlan(context.Background(), &plan)
+	assert.ErrorContains(t, err, "cannot execute plan: request 1 (POST /api/images (body omitted)) has no recorded body")
+	assert.Empty(t, ts.received())
+}
+
+func TestExecutePlan(t *testing.T) {
+	var plan Plan
+	rehearsal := NewClient("test-api-key", WithBaseURL("http://example.invalid"), WithDryRun(&plan))
+	ctx := context.Background()
+	assert.NoError(t, rehearsal.PruneActivityLogs(ctx))
+	_, err := rehearsal.UpdateCollection(ctx, 4, &Collection{Name: "Binder"})
+	assert.NoError(t, err)
+
+	// Save the plan for review and load it again
+	data, err := json.Marshal(&plan)
+	assert.NoError(t, err)
+	var loaded Plan
+	assert.NoError(t, json.Unmarshal(data, &loaded))
+	assert.Equal(t, plan.Requests(), loaded.Requests())
+
+	ts := newRecordingServer()
+	defer ts.Close()
+
+	// Plans are executed even by a client in dry-run mode
+	client := NewClient("test-api-key", WithBaseURL(ts.URL), WithDryRun(&Plan{}))
+	assert.NoError(t, client.ExecutePlan(ctx, &loaded))
+
+	received := ts.received()
+	if assert.Len(t, received, 2) {
+		assert.Equal(t, "POST /api/users/prune-activity-logs", received[0])
+		assert.Equal(t, "PUT /api/collections/4 "+string(plan.Requests()[1].Body), received[1])
+	}
+}
+
+func TestPlanStringRedactsSecrets(t *testing.T) {
+	var plan Plan
+	client := NewClient("test-api-key", WithBaseURL("http://example.invalid"), WithDryRun(&plan))
+	_, err := client.CreateUser(context.Background(), &CreateUserParams{DisplayName: "Ash", Password: "pikachu123"})
+	assert.NoError(t, err)
+
+	// The plan shown for review hides the password, but still sends it when executed
+	assert.NotContains(t, plan.String(), "pikachu123")
+	assert.Contains(t, plan.String(), `"password":"[REDACTED]"`)
+	assert.Contains(t, plan.String(), `"displayName":"Ash"`)
+
+	data, err := json.Marshal(&plan)
+	assert.NoError(t, err)
+	assert.Contains(t, string(data), "pikachu123")
+
+	ts := newRecordingServer()
+	defer ts.Close()
+	client = NewClient("test-api-key", WithBaseURL(ts.URL))
+	assert.NoError(t, client.ExecutePlan(context.Background(), &plan))
+	if received := ts.received(); assert.Len(t, received, 1) {
+		assert.Contains(t, received[0], `"password":"pikachu123"`)
+	}
+}
+
+func TestExecutePlanStopsAtError(t *testing.T) {
+	var calls int
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer ts.Close()
+
+	var plan Plan
+	assert.NoError(t, json.Unmarshal([]byte(`[
+		{"operation": "DeleteUser", "method": "DELETE", "path": "/api/users/1"},
+		{"operation": "DeleteUser", "method": "DELETE", "path": "/api/users/2"}
+	]`), &plan))
+
+	client := NewClient("test-api-key", WithBaseURL(ts.URL))
+	err := client.ExecutePlan(context.Background(), &plan)
+	assert.True(t, IsNotFound(err))
+	assert.ErrorContains(t, err, "failed to execute request 1 of 2 (DELETE /api/users/1)")
+	assert.Equal(t, 1, calls)
+}
+
+func TestPlanMarshalEmpty(t *testing.T) {
+	var buf bytes.Buffer
+	assert.NoError(t, json.NewEncoder(&buf).Encode(&Plan{}))
+	assert.Equal(t, "[]\n", buf.String())
+}
+
+func TestWithDryRunNilPlan(t *testing.T) {
+	_, err := NewClientWithOptions("test-api-key", WithDryRun(nil))
+	assert.ErrorContains(t, err, "invalid dry run: plan cannot be nil")
+}
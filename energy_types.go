@@ -20,7 +20,7 @@ type EnergyType struct {
 // ListEnergyTypes retrieves a list of energy types
 func (c *Client) ListEnergyTypes(ctx context.Context) ([]EnergyType, error) {
 	var response []EnergyType
-	if err := c.doRequest(ctx, http.MethodGet, "/api/energy-types", nil, &response); err != nil {
+	if err := c.doRequest(ctx, "ListEnergyTypes", http.MethodGet, "/api/energy-types", nil, &response); err != nil {
 		return nil, err
 	}
 	return response, nil
@@ -29,7 +29,7 @@ func (c *Client) ListEnergyTypes(ctx context.Context) ([]EnergyType, error) {
 // GetEnergyType retrieves a single energy type by ID
 func (c *Client) GetEnergyType(ctx context.Context, id int) (*EnergyType, error) {
 	var response EnergyType
-	if err := c.doRequest(ctx, http.MethodGet, fmt.Sprintf("/api/energy-types/%d", id), nil, &response); err != nil {
+	if err := c.doRequest(ctx, "GetEnergyType", http.MethodGet, fmt.Sprintf("/api/energy-types/%d", id), nil, &response); err != nil {
 		return nil, err
 	}
 	return &response, nil
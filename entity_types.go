@@ -3,6 +3,7 @@ package tcgcollector
 import (
 	"context"
 	"fmt"
+	"iter"
 	"net/http"
 	"net/url"
 	"time"
@@ -15,13 +16,7 @@ type ListEntityTypesParams struct {
 }
 
 // ListEntityTypesResponse represents the response from listing entity types
-type ListEntityTypesResponse struct {
-	Items          []EntityType `json:"items"`
-	ItemCount      int          `json:"itemCount"`
-	TotalItemCount int          `json:"totalItemCount"`
-	Page           int          `json:"page"`
-	PageCount      int          `json:"pageCount"`
-}
+type ListEntityTypesResponse = ListResponse[EntityType]
 
 // EntityType represents an entity type
 type EntityType struct {
@@ -49,17 +44,30 @@ func (c *Client) ListEntityTypes(ctx context.Context, params *ListEntityTypesPar
 	}
 
 	var response ListEntityTypesResponse
-	if err := c.doRequest(ctx, http.MethodGet, path, nil, &response); err != nil {
+	if err := c.doRequest(ctx, "ListEntityTypes", http.MethodGet, path, nil, &response); err != nil {
 		return nil, err
 	}
 
 	return &response, nil
 }
 
+// AllEntityTypes iterates over all entity types, fetching pages as needed
+func (c *Client) AllEntityTypes(ctx context.Context, params *ListEntityTypesParams, opts ...PageOption) iter.Seq2[EntityType, error] {
+	var p ListEntityTypesParams
+	if params != nil {
+		p = *params
+	}
+	return paginate(ctx, p.Page, func(ctx context.Context, page int) (Page[EntityType], error) {
+		pageParams := p
+		pageParams.Page = &page
+		return c.ListEntityTypes(ctx, &pageParams)
+	}, opts...)
+}
+
 // GetEntityType retrieves a single entity type by ID
 func (c *Client) GetEntityType(ctx context.Context, id int) (*EntityType, error) {
 	var response EntityType
-	if err := c.doRequest(ctx, http.MethodGet, fmt.Sprintf("/api/entity-types/%d", id), nil, &response); err != nil {
+	if err := c.doRequest(ctx, "GetEntityType", http.MethodGet, fmt.Sprintf("/api/entity-types/%d", id), nil, &response); err != nil {
 		return nil, err
 	}
 
@@ -0,0 +1,113 @@
+package tcgcollector
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// maxErrorBodySnippet is the maximum number of bytes of a non-JSON error body
+// that are included in an APIError message
+const maxErrorBodySnippet = 256
+
+// ErrNotJSON is returned when a successful response has a body that is not JSON
+var ErrNotJSON = errors.New("response is not JSON")
+
+// APIError is returned when the API responds with a non-successful status code
+type APIError struct {
+	StatusCode       int
+	Method           string
+	URL              string
+	RequestID        string
+	Message          string
+	Code             string
+	ValidationErrors []ValidationError
+	Body             []byte
+}
+
+// Error implements the error interface
+func (e *APIError) Error() string {
+	if e.Message != "" || e.Code != "" {
+		return fmt.Sprintf("API error: %s (code: %s)", e.Message, e.Code)
+	}
+
+	msg := fmt.Sprintf("API error: %d %s", e.StatusCode, http.StatusText(e.StatusCode))
+	if snippet := strings.TrimSpace(string(e.Body)); snippet != "" {
+		if len(snippet) > maxErrorBodySnippet {
+			snippet = snippet[:maxErrorBodySnippet] + "..."
+		}
+		msg += ": " + snippet
+	}
+	return msg
+}
+
+// newAPIError builds an APIError from an error response
+func newAPIError(resp *http.Response, body []byte) *APIError {
+	apiErr := &APIError{
+		StatusCode: resp.StatusCode,
+		RequestID:  resp.Header.Get("X-Request-Id"),
+		Body:       body,
+	}
+	if resp.Request != nil {
+		apiErr.Method = resp.Request.Method
+		apiErr.URL = resp.Request.URL.String()
+	}
+
+	// Error bodies that are not JSON (for example HTML pages from proxies) are
+	// kept in Body and reported by status instead
+	var errResp ErrorResponse
+	if err := json.Unmarshal(body, &errResp); err == nil {
+		apiErr.Message = errResp.Message
+		apiErr.Code = errResp.Code
+		apiErr.ValidationErrors = errResp.Violations
+	}
+
+	return apiErr
+}
+
+// hasStatus reports whether err is an APIError with one of the given status codes
+func hasStatus(err error, codes ...int) bool {
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+	for _, code := range codes {
+		if apiErr.StatusCode == code {
+			return true
+		}
+	}
+	return false
+}
+
+// IsNotFound reports whether err is an APIError with status 404
+func IsNotFound(err error) bool {
+	return hasStatus(err, http.StatusNotFound)
+}
+
+// IsUnauthorized reports whether err is an APIError with status 401
+func IsUnauthorized(err error) bool {
+	return hasStatus(err, http.StatusUnauthorized)
+}
+
+// IsForbidden reports whether err is an APIError with status 403
+func IsForbidden(err error) bool {
+	return hasStatus(err, http.StatusForbidden)
+}
+
+// IsRateLimited reports whether err is an APIError with status 429
+func IsRateLimited(err error) bool {
+	return hasStatus(err, http.StatusTooManyRequests)
+}
+
+// IsValidationError reports whether err is an APIError with status 400 or 422
+func IsValidationError(err error) bool {
+	return hasStatus(err, http.StatusBadRequest, http.StatusUnprocessableEntity)
+}
+
+// IsServerError reports whether err is an APIError with a 5xx status
+func IsServerError(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode >= 500
+}
@@ -0,0 +1,110 @@
+package tcgcollector
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAPIError(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-Request-Id", "req-123")
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		w.Write([]byte(`{
+			"message": "Validation failed",
+			"code": "VALIDATION_FAILED",
+			"violations": [
+				{"propertyPath": "name", "message": "This value should not be blank."},
+				{"propertyPath": "isPublic", "message": "This value should be a boolean."}
+			]
+		}`))
+	}))
+	defer ts.Close()
+
+	client := NewClient("test-api-key", WithBaseURL(ts.URL))
+	_, err := client.CreateCollection(context.Background(), &Collection{})
+	assert.Error(t, err)
+	assert.Equal(t, "API error: Validation failed (code: VALIDATION_FAILED)", err.Error())
+
+	var apiErr *APIError
+	assert.True(t, errors.As(err, &apiErr))
+	assert.Equal(t, http.StatusUnprocessableEntity, apiErr.StatusCode)
+	assert.Equal(t, http.MethodPost, apiErr.Method)
+	assert.Equal(t, ts.URL+"/api/collections", apiErr.URL)
+	assert.Equal(t, "req-123", apiErr.RequestID)
+	assert.Equal(t, "Validation failed", apiErr.Message)
+	assert.Equal(t, "VALIDATION_FAILED", apiErr.Code)
+	assert.Equal(t, []ValidationError{
+		{PropertyPath: "name", Message: "This value should not be blank."},
+		{PropertyPath: "isPublic", Message: "This value should be a boolean."},
+	}, apiErr.ValidationErrors)
+	assert.Contains(t, string(apiErr.Body), "VALIDATION_FAILED")
+	assert.True(t, IsValidationError(err))
+	assert.False(t, IsNotFound(err))
+}
+
+func TestAPIErrorNonJSONBody(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		w.WriteHeader(http.StatusBadGateway)
+		w.Write([]byte("<html><body>502 Bad Gateway</body></html>"))
+	}))
+	defer ts.Close()
+
+	client := NewClient("test-api-key", WithBaseURL(ts.URL))
+	_, err := client.GetCard(context.Background(), 1)
+	assert.Error(t, err)
+	assert.Equal(t, "API error: 502 Bad Gateway: <html><body>502 Bad Gateway</body></html>", err.Error())
+	assert.True(t, IsServerError(err))
+
+	var apiErr *APIError
+	assert.True(t, errors.As(err, &apiErr))
+	assert.Empty(t, apiErr.Message)
+	assert.Equal(t, "<html><body>502 Bad Gateway</body></html>", string(apiErr.Body))
+}
+
+func TestAPIErrorLongBodyIsTruncated(t *testing.T) {
+	apiErr := &APIError{
+		StatusCode: http.StatusServiceUnavailable,
+		Body:       []byte(strings.Repeat("x", maxErrorBodySnippet+10)),
+	}
+	assert.Equal(t, "API error: 503 Service Unavailable: "+strings.Repeat("x", maxErrorBodySnippet)+"...", apiErr.Error())
+}
+
+func TestAPIErrorEmptyBody(t *testing.T) {
+	apiErr := &APIError{StatusCode: http.StatusNotFound}
+	assert.Equal(t, "API error: 404 Not Found", apiErr.Error())
+}
+
+func TestAPIErrorHelpers(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		check  func(error) bool
+	}{
+		{"not found", http.StatusNotFound, IsNotFound},
+		{"unauthorized", http.StatusUnauthorized, IsUnauthorized},
+		{"forbidden", http.StatusForbidden, IsForbidden},
+		{"rate limited", http.StatusTooManyRequests, IsRateLimited},
+		{"bad request", http.StatusBadRequest, IsValidationError},
+		{"unprocessable entity", http.StatusUnprocessableEntity, IsValidationError},
+		{"server error", http.StatusInternalServerError, IsServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := fmt.Errorf("wrapped: %w", &APIError{StatusCode: tt.status})
+			assert.True(t, tt.check(err))
+			assert.False(t, tt.check(&APIError{StatusCode: http.StatusTeapot}))
+			assert.False(t, tt.check(errors.New("plain error")))
+			assert.False(t, tt.check(nil))
+		})
+	}
+}
@@ -3,6 +3,7 @@ package tcgcollector
 import (
 	"context"
 	"fmt"
+	"iter"
 	"net/http"
 	"net/url"
 	"time"
@@ -15,13 +16,7 @@ type ListExpansionPricesParams struct {
 }
 
 // ListExpansionPricesResponse represents the response from listing expansion prices
-type ListExpansionPricesResponse struct {
-	Items          []ExpansionPrice `json:"items"`
-	ItemCount      int              `json:"itemCount"`
-	TotalItemCount int              `json:"totalItemCount"`
-	Page           int              `json:"page"`
-	PageCount      int              `json:"pageCount"`
-}
+type ListExpansionPricesResponse = ListResponse[ExpansionPrice]
 
 // ExpansionPrice represents a price for an expansion
 type ExpansionPrice struct {
@@ -51,17 +46,30 @@ func (c *Client) ListExpansionPrices(ctx context.Context, params *ListExpansionP
 	}
 
 	var response ListExpansionPricesResponse
-	if err := c.doRequest(ctx, http.MethodGet, path, nil, &response); err != nil {
+	if err := c.doRequest(ctx, "ListExpansionPrices", http.MethodGet, path, nil, &response); err != nil {
 		return nil, err
 	}
 
 	return &response, nil
 }
 
+// AllExpansionPrices iterates over all expansion prices, fetching pages as needed
+func (c *Client) AllExpansionPrices(ctx context.Context, params *ListExpansionPricesParams, opts ...PageOption) iter.Seq2[ExpansionPrice, error] {
+	var p ListExpansionPricesParams
+	if params != nil {
+		p = *params
+	}
+	return paginate(ctx, p.Page, func(ctx context.Context, page int) (Page[ExpansionPrice], error) {
+		pageParams := p
+		pageParams.Page = &page
+		return c.ListExpansionPrices(ctx, &pageParams)
+	}, opts...)
+}
+
 // GetExpansionPrice retrieves a single expansion price by ID
 func (c *Client) GetExpansionPrice(ctx context.Context, id int) (*ExpansionPrice, error) {
 	var response ExpansionPrice
-	if err := c.doRequest(ctx, http.MethodGet, fmt.Sprintf("/api/expansion-prices/%d", id), nil, &response); err != nil {
+	if err := c.doRequest(ctx, "GetExpansionPrice", http.MethodGet, fmt.Sprintf("/api/expansion-prices/%d", id), nil, &response); err != nil {
 		return nil, err
 	}
 
@@ -3,6 +3,7 @@ package tcgcollector
 import (
 	"context"
 	"fmt"
+	"iter"
 	"net/http"
 	"net/url"
 	"time"
@@ -15,13 +16,7 @@ type ListExpansionReferencesParams struct {
 }
 
 // ListExpansionReferencesResponse represents the response from listing expansion references
-type ListExpansionReferencesResponse struct {
-	Items          []ExpansionReference `json:"items"`
-	ItemCount      int                  `json:"itemCount"`
-	TotalItemCount int                  `json:"totalItemCount"`
-	Page           int                  `json:"page"`
-	PageCount      int                  `json:"pageCount"`
-}
+type ListExpansionReferencesResponse = ListResponse[ExpansionReference]
 
 // ExpansionReference represents a reference to an expansion
 type ExpansionReference struct {
@@ -50,17 +45,30 @@ func (c *Client) ListExpansionReferences(ctx context.Context, params *ListExpans
 	}
 
 	var response ListExpansionReferencesResponse
-	if err := c.doRequest(ctx, http.MethodGet, path, nil, &response); err != nil {
+	if err := c.doRequest(ctx, "ListExpansionReferences", http.MethodGet, path, nil, &response); err != nil {
 		return nil, err
 	}
 
 	return &response, nil
 }
 
+// AllExpansionReferences iterates over all expansion references, fetching pages as needed
+func (c *Client) AllExpansionReferences(ctx context.Context, params *ListExpansionReferencesParams, opts ...PageOption) iter.Seq2[ExpansionReference, error] {
+	var p ListExpansionReferencesParams
+	if params != nil {
+		p = *params
+	}
+	return paginate(ctx, p.Page, func(ctx context.Context, page int) (Page[ExpansionReference], error) {
+		pageParams := p
+		pageParams.Page = &page
+		return c.ListExpansionReferences(ctx, &pageParams)
+	}, opts...)
+}
+
 // GetExpansionReference retrieves a single expansion reference by ID
 func (c *Client) GetExpansionReference(ctx context.Context, id int) (*ExpansionReference, error) {
 	var response ExpansionReference
-	if err := c.doRequest(ctx, http.MethodGet, fmt.Sprintf("/api/expansion-references/%d", id), nil, &response); err != nil {
+	if err := c.doRequest(ctx, "GetExpansionReference", http.MethodGet, fmt.Sprintf("/api/expansion-references/%d", id), nil, &response); err != nil {
 		return nil, err
 	}
 
@@ -3,6 +3,7 @@ package tcgcollector
 import (
 	"context"
 	"fmt"
+	"iter"
 	"net/http"
 	"net/url"
 	"time"
@@ -15,13 +16,7 @@ type ListExpansionSeriesParams struct {
 }
 
 // ListExpansionSeriesResponse represents the response from listing expansion series
-type ListExpansionSeriesResponse struct {
-	Items          []ExpansionSeries `json:"items"`
-	ItemCount      int               `json:"itemCount"`
-	TotalItemCount int               `json:"totalItemCount"`
-	Page           int               `json:"page"`
-	PageCount      int               `json:"pageCount"`
-}
+type ListExpansionSeriesResponse = ListResponse[ExpansionSeries]
 
 // ExpansionSeries represents a series of expansions
 type ExpansionSeries struct {
@@ -49,17 +44,30 @@ func (c *Client) ListExpansionSeries(ctx context.Context, params *ListExpansionS
 	}
 
 	var response ListExpansionSeriesResponse
-	if err := c.doRequest(ctx, http.MethodGet, path, nil, &response); err != nil {
+	if err := c.doRequest(ctx, "ListExpansionSeries", http.MethodGet, path, nil, &response); err != nil {
 		return nil, err
 	}
 
 	return &response, nil
 }
 
+// AllExpansionSeries iterates over all expansion series, fetching pages as needed
+func (c *Client) AllExpansionSeries(ctx context.Context, params *ListExpansionSeriesParams, opts ...PageOption) iter.Seq2[ExpansionSeries, error] {
+	var p ListExpansionSeriesParams
+	if params != nil {
+		p = *params
+	}
+	return paginate(ctx, p.Page, func(ctx context.Context, page int) (Page[ExpansionSeries], error) {
+		pageParams := p
+		pageParams.Page = &page
+		return c.ListExpansionSeries(ctx, &pageParams)
+	}, opts...)
+}
+
 // GetExpansionSeries retrieves a single expansion series by ID
 func (c *Client) GetExpansionSeries(ctx context.Context, id int) (*ExpansionSeries, error) {
 	var response ExpansionSeries
-	if err := c.doRequest(ctx, http.MethodGet, fmt.Sprintf("/api/expansion-series/%d", id), nil, &response); err != nil {
+	if err := c.doRequest(ctx, "GetExpansionSeries", http.MethodGet, fmt.Sprintf("/api/expansion-series/%d", id), nil, &response); err != nil {
 		return nil, err
 	}
 
@@ -20,7 +20,7 @@ type Expansion struct {
 // ListExpansions retrieves a list of expansions
 func (c *Client) ListExpansions(ctx context.Context) ([]Expansion, error) {
 	var response []Expansion
-	if err := c.doRequest(ctx, http.MethodGet, "/api/expansions", nil, &response); err != nil {
+	if err := c.doRequest(ctx, "ListExpansions", http.MethodGet, "/api/expansions", nil, &response); err != nil {
 		return nil, err
 	}
 	return response, nil
@@ -29,7 +29,7 @@ func (c *Client) ListExpansions(ctx context.Context) ([]Expansion, error) {
 // GetExpansion retrieves a single expansion by ID
 func (c *Client) GetExpansion(ctx context.Context, id int) (*Expansion, error) {
 	var response Expansion
-	if err := c.doRequest(ctx, http.MethodGet, fmt.Sprintf("/api/expansions/%d", id), nil, &response); err != nil {
+	if err := c.doRequest(ctx, "GetExpansion", http.MethodGet, fmt.Sprintf("/api/expansions/%d", id), nil, &response); err != nil {
 		return nil, err
 	}
 	return &response, nil
@@ -37,10 +37,10 @@ func (c *Client) GetExpansion(ctx context.Context, id int) (*Expansion, error) {
 
 // RecalculateCardCounts recalculates card counts for all expansions
 func (c *Client) RecalculateExpansionCardCounts(ctx context.Context) error {
-	return c.doRequest(ctx, http.MethodPost, "/api/expansions/recalculate-card-counts", nil, nil)
+	return c.doRequest(ctx, "RecalculateExpansionCardCounts", http.MethodPost, "/api/expansions/recalculate-card-counts", nil, nil)
 }
 
 // RegenerateSlugs regenerates slugs for all expansions
 func (c *Client) RegenerateExpansionSlugs(ctx context.Context) error {
-	return c.doRequest(ctx, http.MethodPost, "/api/expansions/regenerate-slugs", nil, nil)
+	return c.doRequest(ctx, "RegenerateExpansionSlugs", http.MethodPost, "/api/expansions/regenerate-slugs", nil, nil)
 }
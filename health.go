@@ -15,7 +15,7 @@ type HealthStatus struct {
 // GetHealth retrieves the health status of the API
 func (c *Client) GetHealth(ctx context.Context) (*HealthStatus, error) {
 	var response HealthStatus
-	if err := c.doRequest(ctx, http.MethodGet, "/api/health", nil, &response); err != nil {
+	if err := c.doRequest(ctx, "GetHealth", http.MethodGet, "/api/health", nil, &response); err != nil {
 		return nil, err
 	}
 
@@ -0,0 +1,49 @@
+package tcgcollector
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"net/http"
+)
+
+// IdempotencyKeyHeader is the header that lets the API recognize a retried
+// create request and apply it only once
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+// IdempotencyKey sends key as the Idempotency-Key of a call instead of a
+// generated one. Reuse the key when repeating a call whose outcome is unknown,
+// e.g. after a timeout, so that the API does not apply it twice
+func IdempotencyKey(key string) CallOption {
+	return SetHeader(IdempotencyKeyHeader, key)
+}
+
+// doIdempotentRequest performs a create request with an Idempotency-Key header,
+// generating one unless the caller supplied it. The key is sent with every
+// retry of the request, which lets the retry policy retry it
+func (c *Client) doIdempotentRequest(ctx context.Context, name, method, path string, body interface{}, result interface{}) error {
+	return c.doRequest(withIdempotencyKey(ctx), name, method, path, body, result)
+}
+
+// withIdempotencyKey returns a context that sends a generated Idempotency-Key
+// with its calls unless ctx already supplies one
+func withIdempotencyKey(ctx context.Context) context.Context {
+	if callOptionsFromContext(ctx).header.Get(IdempotencyKeyHeader) != "" {
+		return ctx
+	}
+	return WithCallOptions(ctx, IdempotencyKey(newIdempotencyKey()))
+}
+
+// newIdempotencyKey returns a random version 4 UUID
+func newIdempotencyKey() string {
+	var b [16]byte
+	rand.Read(b[:])
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
+
+// hasIdempotencyKey reports whether a request carries an Idempotency-Key
+func hasIdempotencyKey(header http.Header) bool {
+	return header.Get(IdempotencyKeyHeader) != ""
+}
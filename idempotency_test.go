@@ -0,0 +1,121 @@
+package tcgcollector
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// newFlakyCreateServer returns a test server that fails the first attempt of
+// every create request with 503 and records the Idempotency-Key of each attempt
+func newFlakyCreateServer(t *testing.T) (*httptest.Server, func() []string) {
+	var mu sync.Mutex
+	var keys []string
+	seen := map[string]bool{}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		key := r.Header.Get(IdempotencyKeyHeader)
+
+		mu.Lock()
+		keys = append(keys, key)
+		first := !seen[key]
+		seen[key] = true
+		mu.Unlock()
+
+		if first {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`{"id": 1}`))
+	}))
+	return ts, func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]string(nil), keys...)
+	}
+}
+
+func TestCreateOperationsRetryWithIdempotencyKey(t *testing.T) {
+	uuid := regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+	tests := []struct {
+		name string
+		call func(c *Client) error
+	}{
+		{"CreateCollection", func(c *Client) error {
+			_, err := c.CreateCollection(context.Background(), &Collection{Name: "Binder"})
+			return err
+		}},
+		{"AddCardToCollection", func(c *Client) error {
+			_, err := c.AddCardToCollection(context.Background(), 1, &CollectionCard{CardID: 25})
+			return err
+		}},
+		{"CreateCardGrade", func(c *Client) error {
+			_, err := c.CreateCardGrade(context.Background(), &CardGrade{})
+			return err
+		}},
+		{"CreateCardVariant", func(c *Client) error {
+			_, err := c.CreateCardVariant(context.Background(), &CardVariant{})
+			return err
+		}},
+		{"CreateCardVariantType", func(c *Client) error {
+			_, err := c.CreateCardVariantType(context.Background(), &CardVariantType{})
+			return err
+		}},
+		{"CreateNewsPost", func(c *Client) error {
+			_, err := c.CreateNewsPost(context.Background(), &CreateNewsPostRequest{})
+			return err
+		}},
+		{"CreateUser", func(c *Client) error {
+			_, err := c.CreateUser(context.Background(), &CreateUserParams{})
+			return err
+		}},
+		{"CreateImage", func(c *Client) error {
+			_, err := c.CreateImage(context.Background(), &CreateImageParams{})
+			return err
+		}},
+		{"UploadImage", func(c *Client) error {
+			_, err := c.UploadImage(context.Background(), strings.NewReader("image data"), nil)
+			return err
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts, keys := newFlakyCreateServer(t)
+			defer ts.Close()
+
+			client := NewClient("test-api-key", WithBaseURL(ts.URL), WithRetryPolicy(testRetryPolicy()))
+			assert.NoError(t, tt.call(client))
+			assert.NoError(t, tt.call(client))
+
+			// Each call has its own key, which is stable across its retries
+			k := keys()
+			assert.Len(t, k, 4)
+			assert.Regexp(t, uuid, k[0])
+			assert.Equal(t, k[0], k[1])
+			assert.Equal(t, k[2], k[3])
+			assert.NotEqual(t, k[0], k[2])
+		})
+	}
+}
+
+func TestCallerSuppliedIdempotencyKey(t *testing.T) {
+	ts, keys := newFlakyCreateServer(t)
+	defer ts.Close()
+
+	client := NewClient("test-api-key", WithBaseURL(ts.URL), WithRetryPolicy(testRetryPolicy()))
+	ctx := WithCallOptions(context.Background(), IdempotencyKey("order-42"))
+	_, err := client.CreateCollection(ctx, &Collection{Name: "Binder"})
+	assert.NoError(t, err)
+
+	// Repeating the call with the same key is recognized by the API
+	_, err = client.CreateCollection(ctx, &Collection{Name: "Binder"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"order-42", "order-42", "order-42"}, keys())
+}
@@ -0,0 +1,253 @@
+package tcgcollector
+
+import (
+	"context"
+	"crypto/md5"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"math"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+const (
+	// cardWidthInches and cardHeightInches are the print size of a trading card
+	cardWidthInches  = 2.5
+	cardHeightInches = 3.5
+)
+
+// ErrChecksumMismatch is returned when a downloaded image does not match the
+// length or digest announced by the server
+var ErrChecksumMismatch = errors.New("image checksum mismatch")
+
+// DownloadImageOptions selects the rendition of an image to download
+type DownloadImageOptions struct {
+	// Width and Height are the minimum size of the rendition in pixels
+	Width  int
+	Height int
+
+	// DPI is the minimum resolution at which the rendition must print at
+	// PrintWidth by PrintHeight inches. The print size defaults to that of a
+	// trading card, 2.5 by 3.5 inches
+	DPI         float64
+	PrintWidth  float64
+	PrintHeight float64
+}
+
+// targetSize returns the minimum size in pixels requested by the options
+func (o *DownloadImageOptions) targetSize() (width, height int) {
+	if o == nil {
+		return 0, 0
+	}
+	width, height = o.Width, o.Height
+	if o.DPI > 0 {
+		printWidth, printHeight := o.PrintWidth, o.PrintHeight
+		if printWidth == 0 && printHeight == 0 {
+			printWidth, printHeight = cardWidthInches, cardHeightInches
+		}
+		width = max(width, int(math.Ceil(o.DPI*printWidth)))
+		height = max(height, int(math.Ceil(o.DPI*printHeight)))
+	}
+	return width, height
+}
+
+// WithImageCache keeps images downloaded by DownloadImage in dir, keyed by
+// image ID and rendition size, so that repeated downloads are served from
+// disk. The directory is created by the first download that stores an image
+func WithImageCache(dir string) ClientOption {
+	return func(c *Client) {
+		c.imageCacheDir = dir
+	}
+}
+
+// BestSize returns the smallest rendition of the image, including the original,
+// that is at least width by height pixels. If no rendition is large enough the
+// largest is returned. A width and height of zero select the original image.
+// It returns false if the image has no URLs
+func (img *Image) BestSize(width, height int) (ImageSize, bool) {
+	original := ImageSize{URL: img.URL, Width: img.Width, Height: img.Height}
+	if original.Height > 0 {
+		original.AspectRatio = float64(original.Width) / float64(original.Height)
+	}
+	if width <= 0 && height <= 0 && original.URL != "" {
+		return original, true
+	}
+
+	var best, largest ImageSize
+	found := false
+	for _, size := range append([]ImageSize{original}, img.Sizes...) {
+		if size.URL == "" {
+			continue
+		}
+		area := size.Width * size.Height
+		if largest.URL == "" || area > largest.Width*largest.Height {
+			largest = size
+		}
+		if size.Width >= width && size.Height >= height && (!found || area < best.Width*best.Height) {
+			best = size
+			found = true
+		}
+	}
+
+	if found {
+		return best, true
+	}
+	return largest, largest.URL != ""
+}
+
+// DownloadImage writes the smallest rendition of img that satisfies opts to w
+// and returns it. The download is verified against the Content-Length,
+// Content-MD5 and Repr-Digest headers of the response; on ErrChecksumMismatch
+// w has received corrupt data. With WithImageCache, renditions are read from
+// and stored in the cache
+func (c *Client) DownloadImage(ctx context.Context, img *Image, w io.Writer, opts *DownloadImageOptions) (*ImageSize, error) {
+	size, ok := img.BestSize(opts.targetSize())
+	if !ok {
+		return nil, fmt.Errorf("image %d has no URL", img.ID)
+	}
+
+	if c.imageCacheDir == "" {
+		if err := c.downloadImage(ctx, size.URL, w); err != nil {
+			return nil, err
+		}
+		return &size, nil
+	}
+
+	path := filepath.Join(c.imageCacheDir, imageCacheKey(img.ID, size))
+	if f, err := os.Open(path); err == nil {
+		defer f.Close()
+		if _, err := io.Copy(w, f); err != nil {
+			return nil, fmt.Errorf("failed to read cached image: %w", err)
+		}
+		return &size, nil
+	}
+
+	if err := os.MkdirAll(c.imageCacheDir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create image cache directory: %w", err)
+	}
+	// Write to a temporary file first so that readers never see a partial image
+	tmp, err := os.CreateTemp(c.imageCacheDir, ".tmp-*")
+	if err != nil {
+		return nil, fmt.Errorf("failed to cache image: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	err = c.downloadImage(ctx, size.URL, io.MultiWriter(w, tmp))
+	if closeErr := tmp.Close(); err == nil && closeErr != nil {
+		err = fmt.Errorf("failed to cache image: %w", closeErr)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return nil, fmt.Errorf("failed to cache image: %w", err)
+	}
+	return &size, nil
+}
+
+// imageCacheKey returns the file name of a rendition of an image in the cache.
+// A hash of the URL keeps renditions apart when an image is replaced
+func imageCacheKey(id int, size ImageSize) string {
+	sum := sha256.Sum256([]byte(size.URL))
+	return fmt.Sprintf("%d-%dx%d-%s", id, size.Width, size.Height, hex.EncodeToString(sum[:8]))
+}
+
+// downloadImage streams the image at rawURL to w. Relative URLs are resolved
+// against the base URL. The download runs through the client's middleware and
+// policies like any streamed request, but images are public, so no token is sent
+func (c *Client) downloadImage(ctx context.Context, rawURL string, w io.Writer) error {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return fmt.Errorf("failed to parse image URL: %w", err)
+	}
+	imageURL := c.baseURL.ResolveReference(u)
+
+	op := newOperation("DownloadImage", http.MethodGet, imageURL.String(), nil)
+	op.PathTemplate = pathTemplate(imageURL.Path)
+	op.Header.Set("Accept", "image/*")
+	op.download = true
+	return c.doStream(ctx, op, func(r io.Reader, header http.Header) error {
+		verifier := newDigestVerifier(header)
+		n, err := io.Copy(io.MultiWriter(w, verifier), r)
+		if err != nil {
+			return fmt.Errorf("failed to download image: %w", err)
+		}
+		// The length is removed from the headers of a decompressed body
+		if length, err := strconv.ParseInt(header.Get("Content-Length"), 10, 64); err == nil && n != length {
+			return fmt.Errorf("%w: received %d of %d bytes", ErrChecksumMismatch, n, length)
+		}
+		return verifier.verify()
+	})
+}
+
+// digestVerifier hashes a response body and compares it with the digests
+// announced in the response headers
+type digestVerifier struct {
+	hashes  []hash.Hash
+	names   []string
+	digests [][]byte
+}
+
+// newDigestVerifier collects the digests of a response it can verify: the
+// Content-MD5 header, which is removed when the body is decompressed, and the
+// sha-256 and sha-512 values of the Repr-Digest header
+func newDigestVerifier(header http.Header) *digestVerifier {
+	v := &digestVerifier{}
+	add := func(name string, h hash.Hash, encoded string) {
+		digest, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			return
+		}
+		v.hashes = append(v.hashes, h)
+		v.names = append(v.names, name)
+		v.digests = append(v.digests, digest)
+	}
+
+	if md5Header := header.Get("Content-MD5"); md5Header != "" {
+		add("md5", md5.New(), md5Header)
+	}
+	// Repr-Digest is a dictionary of byte sequences, e.g. sha-256=:base64:
+	for _, member := range strings.Split(header.Get("Repr-Digest"), ",") {
+		name, value, ok := strings.Cut(strings.TrimSpace(member), "=")
+		if !ok {
+			continue
+		}
+		value = strings.Trim(value, ":")
+		switch strings.ToLower(name) {
+		case "sha-256":
+			add(name, sha256.New(), value)
+		case "sha-512":
+			add(name, sha512.New(), value)
+		}
+	}
+	return v
+}
+
+// Write hashes p
+func (v *digestVerifier) Write(p []byte) (int, error) {
+	for _, h := range v.hashes {
+		h.Write(p)
+	}
+	return len(p), nil
+}
+
+// verify compares the hashes of the body with the announced digests
+func (v *digestVerifier) verify() error {
+	for i, h := range v.hashes {
+		if sum := h.Sum(nil); string(sum) != string(v.digests[i]) {
+			return fmt.Errorf("%w: %s digest is %s, expected %s", ErrChecksumMismatch, v.names[i],
+				base64.StdEncoding.EncodeToString(sum), base64.StdEncoding.EncodeToString(v.digests[i]))
+		}
+	}
+	return nil
+}
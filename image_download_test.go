@@ -0,0 +1,217 @@
+package tcgcollector
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/md5"
+	"crypto/sha256"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testImage() *Image {
+	return &Image{
+		ID:     7,
+		URL:    "/images/7/original.png",
+		Width:  1500,
+		Height: 2100,
+		Sizes: []ImageSize{
+			{URL: "/images/7/large.png", Width: 750, Height: 1050},
+			{URL: "/images/7/small.png", Width: 250, Height: 350},
+			{URL: "/images/7/medium.png", Width: 500, Height: 700},
+		},
+	}
+}
+
+func TestImageBestSize(t *testing.T) {
+	img := testImage()
+
+	size, ok := img.BestSize(0, 0)
+	assert.True(t, ok)
+	assert.Equal(t, "/images/7/original.png", size.URL)
+
+	size, _ = img.BestSize(300, 0)
+	assert.Equal(t, "/images/7/medium.png", size.URL)
+
+	size, _ = img.BestSize(0, 1050)
+	assert.Equal(t, "/images/7/large.png", size.URL)
+
+	size, _ = img.BestSize(100, 100)
+	assert.Equal(t, "/images/7/small.png", size.URL)
+
+	// Nothing is large enough, so the largest rendition is used
+	size, _ = img.BestSize(4000, 0)
+	assert.Equal(t, "/images/7/original.png", size.URL)
+
+	_, ok = (&Image{}).BestSize(100, 100)
+	assert.False(t, ok)
+}
+
+func TestDownloadImageOptionsTargetSize(t *testing.T) {
+	width, height := (*DownloadImageOptions)(nil).targetSize()
+	assert.Equal(t, 0, width)
+	assert.Equal(t, 0, height)
+
+	width, height = (&DownloadImageOptions{DPI: 300}).targetSize()
+	assert.Equal(t, 750, width)
+	assert.Equal(t, 1050, height)
+
+	width, height = (&DownloadImageOptions{DPI: 100, PrintWidth: 4, Height: 600}).targetSize()
+	assert.Equal(t, 400, width)
+	assert.Equal(t, 600, height)
+}
+
+func newImageServer(t *testing.T, data []byte, header func(http.Header)) (*httptest.Server, *int32) {
+	var requests int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		assert.Empty(t, r.Header.Get("Authorization"))
+		if r.URL.Path != "/images/7/medium.png" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "image/png")
+		if header != nil {
+			header(w.Header())
+		}
+		w.Write(data)
+	}))
+	return ts, &requests
+}
+
+func TestDownloadImage(t *testing.T) {
+	data := bytes.Repeat([]byte("png"), 1000)
+	sha := sha256.Sum256(data)
+	sum := md5.Sum(data)
+	ts, _ := newImageServer(t, data, func(h http.Header) {
+		h.Set("Content-MD5", base64.StdEncoding.EncodeToString(sum[:]))
+		h.Set("Repr-Digest", "sha-256=:"+base64.StdEncoding.EncodeToString(sha[:])+":")
+	})
+	defer ts.Close()
+
+	client := NewClient("test-api-key", WithBaseURL(ts.URL))
+
+	var buf bytes.Buffer
+	size, err := client.DownloadImage(context.Background(), testImage(), &buf, &DownloadImageOptions{Width: 400})
+	assert.NoError(t, err)
+	assert.Equal(t, 500, size.Width)
+	assert.Equal(t, data, buf.Bytes())
+}
+
+func TestDownloadImageChecksumMismatch(t *testing.T) {
+	sha := sha256.Sum256([]byte("something else"))
+	ts, _ := newImageServer(t, []byte("png"), func(h http.Header) {
+		h.Set("Repr-Digest", "sha-256=:"+base64.StdEncoding.EncodeToString(sha[:])+":")
+	})
+	defer ts.Close()
+
+	dir := t.TempDir()
+	client := NewClient("test-api-key", WithBaseURL(ts.URL), WithImageCache(dir))
+
+	var buf bytes.Buffer
+	_, err := client.DownloadImage(context.Background(), testImage(), &buf, &DownloadImageOptions{Width: 400})
+	assert.ErrorIs(t, err, ErrChecksumMismatch)
+
+	// A corrupt image is not cached
+	entries, _ := os.ReadDir(dir)
+	assert.Empty(t, entries)
+}
+
+func TestDownloadImageNotFound(t *testing.T) {
+	ts, _ := newImageServer(t, nil, nil)
+	defer ts.Close()
+
+	client := NewClient("test-api-key", WithBaseURL(ts.URL))
+	_, err := client.DownloadImage(context.Background(), testImage(), &bytes.Buffer{}, nil)
+	assert.True(t, IsNotFound(err))
+
+	_, err = client.DownloadImage(context.Background(), &Image{ID: 8}, &bytes.Buffer{}, nil)
+	assert.EqualError(t, err, "image 8 has no URL")
+}
+
+func TestDownloadImageCache(t *testing.T) {
+	data := []byte("png data")
+	ts, requests := newImageServer(t, data, nil)
+	defer ts.Close()
+
+	dir := filepath.Join(t.TempDir(), "images")
+	client := NewClient("test-api-key", WithBaseURL(ts.URL), WithImageCache(dir))
+
+	for range 3 {
+		var buf bytes.Buffer
+		size, err := client.DownloadImage(context.Background(), testImage(), &buf, &DownloadImageOptions{Width: 500, Height: 700})
+		assert.NoError(t, err)
+		assert.Equal(t, "/images/7/medium.png", size.URL)
+		assert.Equal(t, data, buf.Bytes())
+	}
+	assert.Equal(t, int32(1), atomic.LoadInt32(requests))
+
+	matches, _ := filepath.Glob(filepath.Join(dir, "7-500x700-*"))
+	assert.Len(t, matches, 1)
+}
+
+func TestWithImageCacheInvalidDirectory(t *testing.T) {
+	ts, _ := newImageServer(t, []byte("png"), nil)
+	defer ts.Close()
+
+	file := filepath.Join(t.TempDir(), "file")
+	assert.NoError(t, os.WriteFile(file, nil, 0o644))
+
+	// The directory is only created when an image is stored
+	client, err := NewClientWithOptions("test-api-key", WithBaseURL(ts.URL), WithImageCache(filepath.Join(file, "images")))
+	assert.NoError(t, err)
+	_, err = client.DownloadImage(context.Background(), testImage(), &bytes.Buffer{}, &DownloadImageOptions{Width: 400})
+	assert.ErrorContains(t, err, "failed to create image cache directory")
+}
+
+func TestDownloadImageUsesClientPolicies(t *testing.T) {
+	data := bytes.Repeat([]byte("png"), 1000)
+	sum := md5.Sum(data)
+	var requests int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Empty(t, r.Header.Get("Authorization"))
+		assert.Equal(t, "image/*", r.Header.Get("Accept"))
+		assert.Equal(t, "yes", r.Header.Get("X-Call"))
+		if atomic.AddInt32(&requests, 1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+
+		// The digest of the compressed body is not checked against the decompressed image
+		w.Header().Set("Content-Encoding", "gzip")
+		w.Header().Set("Content-MD5", base64.StdEncoding.EncodeToString(sum[:]))
+		zw := gzip.NewWriter(w)
+		zw.Write(data)
+		zw.Close()
+	}))
+	defer ts.Close()
+
+	var operations []string
+	client := NewClient("test-api-key",
+		WithBaseURL(ts.URL),
+		WithRetryPolicy(testRetryPolicy()),
+		WithMiddleware(func(next Handler) Handler {
+			return func(ctx context.Context, op *Operation) (*Response, error) {
+				operations = append(operations, op.Name+" "+op.PathTemplate)
+				return next(ctx, op)
+			}
+		}),
+	)
+
+	var meta ResponseMeta
+	ctx := WithCallOptions(context.Background(), SetHeader("X-Call", "yes"), CaptureResponse(&meta))
+	var buf bytes.Buffer
+	_, err := client.DownloadImage(ctx, testImage(), &buf, &DownloadImageOptions{Width: 400})
+	assert.NoError(t, err)
+	assert.Equal(t, data, buf.Bytes())
+	assert.Equal(t, []string{"DownloadImage /images/{id}/medium.png"}, operations)
+	assert.Equal(t, 2, meta.Attempts)
+}
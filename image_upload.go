@@ -0,0 +1,194 @@
+package tcgcollector
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"strings"
+)
+
+// sniffLen is the number of bytes http.DetectContentType considers
+const sniffLen = 512
+
+// UploadImageParams contains the parameters for uploading an image
+type UploadImageParams struct {
+	// Filename is the name of the uploaded file. It defaults to "image"
+	Filename string
+
+	// ContentType is the media type of the image. It is detected from the
+	// start of the image when empty
+	ContentType string
+
+	// Type is the intended use of the image, e.g. ImageTypeCardImage
+	Type ImageType
+
+	// Progress is called with the number of bytes of the image sent so far
+	Progress func(sent int64)
+}
+
+// UploadImage creates a new image from r, streaming it to the API as
+// multipart/form-data without holding it in memory. If the request has to
+// be sent again, e.g. after the token was refreshed or when it is retried with
+// its Idempotency-Key, r is rewound when it implements io.Seeker; otherwise
+// the upload fails
+func (c *Client) UploadImage(ctx context.Context, r io.Reader, params *UploadImageParams) (*Image, error) {
+	upload := &imageUpload{reader: r}
+	if params != nil {
+		upload.params = *params
+	}
+	if upload.params.Filename == "" {
+		upload.params.Filename = "image"
+	}
+
+	op := newOperation("UploadImage", http.MethodPost, "/api/images", nil)
+	op.openBody = upload.open
+	resp, err := c.perform(withIdempotencyKey(ctx), op, c.transport())
+	// The image must no longer be read once the call returns
+	upload.close()
+	if err != nil {
+		return nil, err
+	}
+
+	var response Image
+	if err := decodeResponse(resp, &response); err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
+
+// imageUpload encodes an image and its metadata as a multipart form
+type imageUpload struct {
+	reader io.Reader
+	params UploadImageParams
+
+	opened bool
+	start  int64
+
+	// body is the form of the last attempt and done is closed when the
+	// goroutine writing it has exited
+	body *io.PipeReader
+	done chan struct{}
+}
+
+// open returns a reader of the multipart form, which is written by a
+// goroutine as the request is sent, and its content type
+func (u *imageUpload) open() (io.Reader, string, error) {
+	// The previous attempt may still be reading the image, e.g. when the API
+	// rejected it before the whole body was sent
+	u.close()
+	if err := u.rewind(); err != nil {
+		return nil, "", err
+	}
+
+	pr, pw := io.Pipe()
+	form := multipart.NewWriter(pw)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		pw.CloseWithError(u.write(form))
+	}()
+	u.body, u.done = pr, done
+	return pr, form.FormDataContentType(), nil
+}
+
+// close stops the goroutine writing the form of the last attempt and waits
+// for it to exit
+func (u *imageUpload) close() {
+	if u.body == nil {
+		return
+	}
+	u.body.Close()
+	<-u.done
+	u.body, u.done = nil, nil
+}
+
+// rewind moves the reader back to the start of the image when it is opened again
+func (u *imageUpload) rewind() error {
+	seeker, ok := u.reader.(io.Seeker)
+	if !u.opened {
+		u.opened = true
+		if ok {
+			start, err := seeker.Seek(0, io.SeekCurrent)
+			if err != nil {
+				return fmt.Errorf("failed to rewind image: %w", err)
+			}
+			u.start = start
+		}
+		return nil
+	}
+
+	if !ok {
+		return errors.New("failed to rewind image: reader does not implement io.Seeker")
+	}
+	if _, err := seeker.Seek(u.start, io.SeekStart); err != nil {
+		return fmt.Errorf("failed to rewind image: %w", err)
+	}
+	return nil
+}
+
+// write writes the metadata and the image to the form
+func (u *imageUpload) write(form *multipart.Writer) error {
+	if u.params.Type != "" {
+		if err := form.WriteField("type", string(u.params.Type)); err != nil {
+			return err
+		}
+	}
+
+	image := bufio.NewReaderSize(u.reader, sniffLen)
+	contentType := u.params.ContentType
+	if contentType == "" {
+		head, err := image.Peek(sniffLen)
+		if err != nil && err != io.EOF {
+			return fmt.Errorf("failed to read image: %w", err)
+		}
+		contentType = http.DetectContentType(head)
+	}
+
+	header := make(textproto.MIMEHeader)
+	header.Set("Content-Disposition", fmt.Sprintf(`form-data; name="file"; filename="%s"`, escapeQuotes(u.params.Filename)))
+	header.Set("Content-Type", contentType)
+	part, err := form.CreatePart(header)
+	if err != nil {
+		return err
+	}
+
+	var src io.Reader = image
+	if u.params.Progress != nil {
+		src = &progressReader{reader: image, progress: u.params.Progress}
+	}
+	if _, err := io.Copy(part, src); err != nil {
+		return fmt.Errorf("failed to read image: %w", err)
+	}
+	return form.Close()
+}
+
+// quoteEscaper escapes a filename like mime/multipart does
+var quoteEscaper = strings.NewReplacer("\\", "\\\\", `"`, "\\\"")
+
+// escapeQuotes escapes backslashes and quotes in a quoted header parameter
+func escapeQuotes(s string) string {
+	return quoteEscaper.Replace(s)
+}
+
+// progressReader reports the number of bytes read so far
+type progressReader struct {
+	reader   io.Reader
+	progress func(sent int64)
+	sent     int64
+}
+
+// Read reads from the underlying reader and reports progress
+func (r *progressReader) Read(p []byte) (int, error) {
+	n, err := r.reader.Read(p)
+	if n > 0 {
+		r.sent += int64(n)
+		r.progress(r.sent)
+	}
+	return n, err
+}
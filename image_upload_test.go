@@ -0,0 +1,186 @@
+package tcgcollector
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// pngHeader is the signature of a PNG file
+var pngHeader = []byte("\x89PNG\r\n\x1a\n")
+
+func TestUploadImage(t *testing.T) {
+	image := append(bytes.Clone(pngHeader), bytes.Repeat([]byte{1}, 100_000)...)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/api/images", r.URL.Path)
+		assert.True(t, strings.HasPrefix(r.Header.Get("Content-Type"), "multipart/form-data; boundary="))
+		assert.Equal(t, int64(-1), r.ContentLength)
+
+		reader, err := r.MultipartReader()
+		if !assert.NoError(t, err) {
+			return
+		}
+		part, err := reader.NextPart()
+		assert.NoError(t, err)
+		assert.Equal(t, "type", part.FormName())
+		value, _ := io.ReadAll(part)
+		assert.Equal(t, "CardImage", string(value))
+
+		part, err = reader.NextPart()
+		assert.NoError(t, err)
+		assert.Equal(t, "file", part.FormName())
+		assert.Equal(t, `scan "front".png`, part.FileName())
+		assert.Equal(t, "image/png", part.Header.Get("Content-Type"))
+		data, _ := io.ReadAll(part)
+		assert.Equal(t, image, data)
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id": 1, "contentType": "image/png"}`))
+	}))
+	defer ts.Close()
+
+	client := NewClient("test-api-key", WithBaseURL(ts.URL))
+
+	var progress []int64
+	result, err := client.UploadImage(context.Background(), io.MultiReader(bytes.NewReader(image)), &UploadImageParams{
+		Filename: `scan "front".png`,
+		Type:     ImageTypeCardImage,
+		Progress: func(sent int64) { progress = append(progress, sent) },
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, result.ID)
+	if assert.NotEmpty(t, progress) {
+		assert.Equal(t, int64(len(image)), progress[len(progress)-1])
+	}
+}
+
+func TestUploadImageDefaults(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		file, header, err := r.FormFile("file")
+		if !assert.NoError(t, err) {
+			return
+		}
+		defer file.Close()
+		assert.Equal(t, "image", header.Filename)
+		assert.Equal(t, "image/webp", header.Header.Get("Content-Type"))
+		assert.Empty(t, r.FormValue("type"))
+		w.Write([]byte(`{"id": 2}`))
+	}))
+	defer ts.Close()
+
+	client := NewClient("test-api-key", WithBaseURL(ts.URL))
+	result, err := client.UploadImage(context.Background(), strings.NewReader("tiny"), &UploadImageParams{ContentType: "image/webp"})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, result.ID)
+}
+
+func TestUploadImageRewindsOnReauthentication(t *testing.T) {
+	var attempts int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		file, _, err := r.FormFile("file")
+		if !assert.NoError(t, err) {
+			return
+		}
+		defer file.Close()
+		data, _ := io.ReadAll(file)
+		assert.Equal(t, "image data", string(data))
+
+		if atomic.AddInt32(&attempts, 1) == 1 {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Write([]byte(`{"id": 3}`))
+	}))
+	defer ts.Close()
+
+	source := &countingTokenSource{}
+	client := NewClient("", WithBaseURL(ts.URL), WithTokenSource(source))
+
+	result, err := client.UploadImage(context.Background(), strings.NewReader("image data"), nil)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, result.ID)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&attempts))
+}
+
+func TestUploadImageWaitsForPreviousAttempt(t *testing.T) {
+	image := append(bytes.Clone(pngHeader), bytes.Repeat([]byte{7}, 32<<20)...)
+	var attempts int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// The first attempt is rejected before its body has been read
+		if atomic.AddInt32(&attempts, 1) == 1 {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		file, _, err := r.FormFile("file")
+		if !assert.NoError(t, err) {
+			return
+		}
+		defer file.Close()
+		data, _ := io.ReadAll(file)
+		assert.True(t, bytes.Equal(image, data), "Expected the whole image to be sent again")
+		w.Write([]byte(`{"id": 4}`))
+	}))
+	defer ts.Close()
+
+	client := NewClient("", WithBaseURL(ts.URL), WithTokenSource(&countingTokenSource{}))
+
+	result, err := client.UploadImage(context.Background(), bytes.NewReader(image), nil)
+	assert.NoError(t, err)
+	assert.Equal(t, 4, result.ID)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&attempts))
+}
+
+func TestUploadImageCannotRewind(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.Copy(io.Discard, r.Body)
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer ts.Close()
+
+	client := NewClient("", WithBaseURL(ts.URL), WithTokenSource(&countingTokenSource{}))
+
+	_, err := client.UploadImage(context.Background(), io.MultiReader(strings.NewReader("image data")), nil)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to rewind image")
+}
+
+func TestUploadImageReadError(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.Copy(io.Discard, r.Body)
+	}))
+	defer ts.Close()
+
+	client := NewClient("test-api-key", WithBaseURL(ts.URL))
+	_, err := client.UploadImage(context.Background(), io.MultiReader(strings.NewReader("partial"), errorReader{}), nil)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to send request")
+}
+
+// errorReader fails every read
+type errorReader struct{}
+
+func (errorReader) Read([]byte) (int, error) {
+	return 0, assert.AnError
+}
+
+// countingTokenSource issues a new token after every invalidation
+type countingTokenSource struct {
+	invalidations int32
+}
+
+func (s *countingTokenSource) Token(context.Context) (string, error) {
+	return fmt.Sprintf("token-%d", atomic.LoadInt32(&s.invalidations)), nil
+}
+
+func (s *countingTokenSource) Invalidate(string) {
+	atomic.AddInt32(&s.invalidations, 1)
+}
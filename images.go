@@ -3,6 +3,7 @@ package tcgcollector
 import (
 	"context"
 	"fmt"
+	"iter"
 	"net/http"
 	"net/url"
 )
@@ -14,13 +15,7 @@ type ListImagesParams struct {
 }
 
 // ListImagesResponse represents the response from listing images
-type ListImagesResponse struct {
-	Items          []Image `json:"items"`
-	ItemCount      int     `json:"itemCount"`
-	TotalItemCount int     `json:"totalItemCount"`
-	Page           int     `json:"page"`
-	PageCount      int     `json:"pageCount"`
-}
+type ListImagesResponse = ListResponse[Image]
 
 // CreateImageParams contains the parameters for creating an image
 type CreateImageParams struct {
@@ -44,27 +39,41 @@ func (c *Client) ListImages(ctx context.Context, params *ListImagesParams) (*Lis
 	}
 
 	var response ListImagesResponse
-	if err := c.doRequest(ctx, http.MethodGet, path, nil, &response); err != nil {
+	if err := c.doRequest(ctx, "ListImages", http.MethodGet, path, nil, &response); err != nil {
 		return nil, err
 	}
 
 	return &response, nil
 }
 
+// AllImages iterates over all images, fetching pages as needed
+func (c *Client) AllImages(ctx context.Context, params *ListImagesParams, opts ...PageOption) iter.Seq2[Image, error] {
+	var p ListImagesParams
+	if params != nil {
+		p = *params
+	}
+	return paginate(ctx, p.Page, func(ctx context.Context, page int) (Page[Image], error) {
+		pageParams := p
+		pageParams.Page = &page
+		return c.ListImages(ctx, &pageParams)
+	}, opts...)
+}
+
 // GetImage retrieves a single image by ID
 func (c *Client) GetImage(ctx context.Context, id int) (*Image, error) {
 	var response Image
-	if err := c.doRequest(ctx, http.MethodGet, fmt.Sprintf("/api/images/%d", id), nil, &response); err != nil {
+	if err := c.doRequest(ctx, "GetImage", http.MethodGet, fmt.Sprintf("/api/images/%d", id), nil, &response); err != nil {
 		return nil, err
 	}
 
 	return &response, nil
 }
 
-// CreateImage creates a new image
+// CreateImage creates a new image. The file is sent base64 encoded in a JSON
+// body; use UploadImage to stream large images instead
 func (c *Client) CreateImage(ctx context.Context, params *CreateImageParams) (*Image, error) {
 	var response Image
-	if err := c.doRequest(ctx, http.MethodPost, "/api/images", params, &response); err != nil {
+	if err := c.doIdempotentRequest(ctx, "CreateImage", http.MethodPost, "/api/images", params, &response); err != nil {
 		return nil, err
 	}
 
@@ -76,13 +85,5 @@ func (c *Client) DeleteImage(ctx context.Context, id int) error {
 	var response struct {
 		Message string `json:"message"`
 	}
-	err := c.doRequest(ctx, http.MethodDelete, fmt.Sprintf("/api/images/%d", id), nil, &response)
-	if err != nil {
-		// If the error is due to EOF and the status code was 204, that's expected
-		if err.Error() == "failed to decode response: EOF" {
-			return nil
-		}
-		return err
-	}
-	return nil
+	return c.doRequest(ctx, "DeleteImage", http.MethodDelete, fmt.Sprintf("/api/images/%d", id), nil, &response)
 }
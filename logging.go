@@ -0,0 +1,172 @@
+package tcgcollector
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	// redacted replaces secret values in log output
+	redacted = "[REDACTED]"
+	// maxLoggedBodySize is the maximum number of body bytes included in a log record
+	maxLoggedBodySize = 4096
+)
+
+// redactedHeaders are the headers whose values are never logged
+var redactedHeaders = []string{"Authorization", "Cookie", "Set-Cookie"}
+
+// redactedFields are the JSON fields whose values are never logged, matched case-insensitively.
+// They cover passwords sent by Login, Register, CreateUser and UpdateUser and the tokens
+// returned by Login, RefreshToken and GenerateAPIAccessToken
+var redactedFields = []string{"password", "token", "accessToken", "apiAccessToken", "refreshToken"}
+
+// LogLevels configures the levels at which requests and responses are logged
+type LogLevels struct {
+	// Request is the level for outgoing requests
+	Request slog.Level
+	// Response is the level for successful responses
+	Response slog.Level
+	// Error is the level for failed attempts
+	Error slog.Level
+}
+
+// DefaultLogLevels logs requests and responses at debug level and failures at warn level
+var DefaultLogLevels = LogLevels{
+	Request:  slog.LevelDebug,
+	Response: slog.LevelDebug,
+	Error:    slog.LevelWarn,
+}
+
+// WithLogger logs every request attempt and its outcome to logger. Credentials are always redacted
+func WithLogger(logger *slog.Logger) ClientOption {
+	return func(c *Client) {
+		c.logger = logger
+	}
+}
+
+// WithLogLevels sets the levels used by the logger configured with WithLogger
+func WithLogLevels(levels LogLevels) ClientOption {
+	return func(c *Client) {
+		c.logLevels = levels
+	}
+}
+
+// logRequest logs an outgoing request attempt
+func (c *Client) logRequest(ctx context.Context, op *Operation, req *http.Request, body []byte, attempt int) {
+	if c.logger == nil || !c.logger.Enabled(ctx, c.logLevels.Request) {
+		return
+	}
+
+	attrs := []slog.Attr{
+		slog.String("operation", op.Name),
+		slog.String("method", req.Method),
+		slog.String("url", req.URL.String()),
+		slog.Int("attempt", attempt+1),
+		slog.Any("headers", redactHeaders(req.Header)),
+	}
+	if body != nil {
+		attrs = append(attrs, slog.String("body", redactBody(body)))
+	}
+	c.logger.LogAttrs(ctx, c.logLevels.Request, "tcgcollector request", attrs...)
+}
+
+// logResponse logs the outcome of a request attempt
+func (c *Client) logResponse(ctx context.Context, op *Operation, resp *Response, err error, attempt int, latency time.Duration) {
+	level := c.logLevels.Response
+	if err != nil {
+		level = c.logLevels.Error
+	}
+	if c.logger == nil || !c.logger.Enabled(ctx, level) {
+		return
+	}
+
+	attrs := []slog.Attr{
+		slog.String("operation", op.Name),
+		slog.String("method", op.Method),
+		slog.String("path", op.PathTemplate),
+		slog.Int("attempt", attempt+1),
+		slog.Duration("latency", latency),
+	}
+	if resp != nil {
+		attrs = append(attrs,
+			slog.Int("status", resp.StatusCode),
+			slog.Any("headers", redactHeaders(resp.Header)),
+			slog.String("body", redactBody(resp.Body)),
+		)
+	}
+	if err != nil {
+		attrs = append(attrs, slog.String("error", err.Error()))
+	}
+	c.logger.LogAttrs(ctx, level, "tcgcollector response", attrs...)
+}
+
+// redactHeaders returns a copy of the headers with secret values replaced
+func redactHeaders(header http.Header) map[string]string {
+	result := make(map[string]string, len(header))
+	for key, values := range header {
+		result[key] = strings.Join(values, ", ")
+	}
+	for _, key := range redactedHeaders {
+		if _, ok := result[key]; ok {
+			result[key] = redacted
+		}
+	}
+	return result
+}
+
+// redactBody returns the body as a string with the values of secret JSON fields replaced
+func redactBody(body []byte) string {
+	body = redactJSON(body)
+	if len(body) > maxLoggedBodySize {
+		return string(body[:maxLoggedBodySize]) + "..."
+	}
+	return string(body)
+}
+
+// redactJSON returns the body with the values of secret JSON fields replaced.
+// Bodies that are not JSON are returned unchanged
+func redactJSON(body []byte) []byte {
+	var value interface{}
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	if err := decoder.Decode(&value); err == nil {
+		if data, err := json.Marshal(redactValue(value)); err == nil {
+			return data
+		}
+	}
+	return body
+}
+
+// redactValue replaces the values of secret fields in a decoded JSON value
+func redactValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, field := range v {
+			if isRedactedField(key) {
+				v[key] = redacted
+			} else {
+				v[key] = redactValue(field)
+			}
+		}
+	case []interface{}:
+		for i, item := range v {
+			v[i] = redactValue(item)
+		}
+	}
+	return value
+}
+
+// isRedactedField reports whether the JSON field holds a secret
+func isRedactedField(name string) bool {
+	for _, field := range redactedFields {
+		if strings.EqualFold(name, field) {
+			return true
+		}
+	}
+	return false
+}
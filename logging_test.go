@@ -0,0 +1,122 @@
+package tcgcollector
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// decodeLogRecords decodes the JSON log records written to buf
+func decodeLogRecords(t *testing.T, buf *bytes.Buffer) []map[string]interface{} {
+	var records []map[string]interface{}
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		var record map[string]interface{}
+		err := json.Unmarshal([]byte(line), &record)
+		assert.NoError(t, err)
+		records = append(records, record)
+	}
+	return records
+}
+
+func TestWithLogger(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"token": "jwt-secret", "expiresAt": "2024-01-01T00:00:00Z", "user": {"id": 1}}`))
+	}))
+	defer ts.Close()
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	client := NewClient("api-key-secret", WithBaseURL(ts.URL), WithLogger(logger))
+
+	_, err := client.Login(context.Background(), &LoginRequest{Username: "ash", Password: "pikachu123"})
+	assert.NoError(t, err)
+
+	output := buf.String()
+	assert.NotContains(t, output, "api-key-secret")
+	assert.NotContains(t, output, "pikachu123")
+	assert.NotContains(t, output, "jwt-secret")
+
+	records := decodeLogRecords(t, &buf)
+	assert.Len(t, records, 2)
+
+	request := records[0]
+	assert.Equal(t, "DEBUG", request["level"])
+	assert.Equal(t, "tcgcollector request", request["msg"])
+	assert.Equal(t, "Login", request["operation"])
+	assert.Equal(t, float64(1), request["attempt"])
+	assert.Equal(t, redacted, request["headers"].(map[string]interface{})["Authorization"])
+	assert.Contains(t, request["body"], `"username":"ash"`)
+
+	response := records[1]
+	assert.Equal(t, "tcgcollector response", response["msg"])
+	assert.Equal(t, "Login", response["operation"])
+	assert.Equal(t, "/api/auth/login", response["path"])
+	assert.Equal(t, float64(http.StatusOK), response["status"])
+	assert.Contains(t, response, "latency")
+	assert.Contains(t, response["body"], `"token":"[REDACTED]"`)
+}
+
+func TestWithLoggerFailedAttempts(t *testing.T) {
+	attempts := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`{"token": "api-token-secret"}`))
+	}))
+	defer ts.Close()
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelInfo}))
+	client := NewClient("test-api-key",
+		WithBaseURL(ts.URL),
+		WithLogger(logger),
+		WithLogLevels(LogLevels{Request: slog.LevelDebug, Response: slog.LevelInfo, Error: slog.LevelError}),
+		WithRetryPolicy(&RetryPolicy{MaxRetries: 1, BaseDelay: time.Millisecond, MaxDelay: time.Millisecond}),
+	)
+
+	// Only idempotent requests are retried, so use a GET to log a failed attempt
+	err := client.doRequest(context.Background(), "Test", http.MethodGet, "/api/users/1/generate-api-access-token", nil, nil)
+	assert.NoError(t, err)
+	assert.NotContains(t, buf.String(), "api-token-secret")
+
+	records := decodeLogRecords(t, &buf)
+	assert.Len(t, records, 2)
+	assert.Equal(t, "ERROR", records[0]["level"])
+	assert.Equal(t, float64(1), records[0]["attempt"])
+	assert.Equal(t, float64(http.StatusServiceUnavailable), records[0]["status"])
+	assert.Contains(t, records[0]["error"], "503 Service Unavailable")
+	assert.Equal(t, "INFO", records[1]["level"])
+	assert.Equal(t, float64(2), records[1]["attempt"])
+}
+
+func TestRedactBody(t *testing.T) {
+	assert.Equal(t, `{"displayName":"Ash","password":"[REDACTED]"}`, redactBody([]byte(`{"displayName": "Ash", "password": "secret"}`)))
+	assert.Equal(t, `[{"Token":"[REDACTED]","id":1}]`, redactBody([]byte(`[{"id": 1, "Token": "secret"}]`)))
+	assert.Equal(t, `{"price":1.10}`, redactBody([]byte(`{"price": 1.10}`)))
+	assert.Equal(t, "not json", redactBody([]byte("not json")))
+	assert.Equal(t, strings.Repeat("x", maxLoggedBodySize)+"...", redactBody([]byte(strings.Repeat("x", maxLoggedBodySize+1))))
+}
+
+func TestRedactHeaders(t *testing.T) {
+	header := http.Header{}
+	header.Set("Authorization", "Bearer secret")
+	header.Add("Accept", "application/json")
+	header.Add("Accept", "text/plain")
+
+	result := redactHeaders(header)
+	assert.Equal(t, redacted, result["Authorization"])
+	assert.Equal(t, "application/json, text/plain", result["Accept"])
+	assert.Equal(t, "Bearer secret", header.Get("Authorization"))
+}
@@ -0,0 +1,266 @@
+package tcgcollector
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"expvar"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// RequestMetrics describes a completed operation
+type RequestMetrics struct {
+	// Operation is the name of the client method, e.g. "ListCards"
+	Operation string
+	// Method is the HTTP method
+	Method string
+	// PathTemplate is the request path with IDs replaced by placeholders, e.g. "/api/cards/{id}"
+	PathTemplate string
+	// StatusCode is the status code of the final response, or 0 if there was none
+	StatusCode int
+	// Duration is the time taken by the operation, including retries
+	Duration time.Duration
+	// Err is the error returned by the operation, if any
+	Err error
+}
+
+// MetricsRecorder records the outcome of every operation performed by the client.
+// Implementations must be safe for concurrent use
+type MetricsRecorder interface {
+	RecordRequest(ctx context.Context, m RequestMetrics)
+}
+
+// WithMetrics records the outcome of every operation with recorder
+func WithMetrics(recorder MetricsRecorder) ClientOption {
+	return func(c *Client) {
+		c.metrics = recorder
+	}
+}
+
+// recordMetrics reports a completed operation to the client's metrics recorder
+func (c *Client) recordMetrics(ctx context.Context, op *Operation, resp *Response, err error, duration time.Duration) {
+	if c.metrics == nil {
+		return
+	}
+
+	m := RequestMetrics{
+		Operation:    op.Name,
+		Method:       op.Method,
+		PathTemplate: op.PathTemplate,
+		Duration:     duration,
+		Err:          err,
+	}
+	var apiErr *APIError
+	switch {
+	case resp != nil:
+		m.StatusCode = resp.StatusCode
+	case errors.As(err, &apiErr):
+		m.StatusCode = apiErr.StatusCode
+	}
+	c.metrics.RecordRequest(ctx, m)
+}
+
+// DefaultLatencyBuckets are the upper bounds in seconds of the latency histogram buckets used by ExpvarMetrics
+var DefaultLatencyBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30}
+
+// ExpvarMetrics is a MetricsRecorder that counts requests per operation,
+// errors per operation and status, and keeps a latency histogram per
+// operation. It is an expvar.Var and serves the metrics in the Prometheus
+// text exposition format as an http.Handler
+type ExpvarMetrics struct {
+	buckets []float64
+
+	mu       sync.Mutex
+	requests map[requestLabels]int64
+	errors   map[errorLabels]int64
+	latency  map[string]*histogram
+}
+
+type requestLabels struct {
+	operation, method, path string
+}
+
+type errorLabels struct {
+	operation, status string
+}
+
+// histogram counts observations per bucket. counts has one more element than
+// the buckets for observations above the largest bound
+type histogram struct {
+	counts []int64
+	count  int64
+	sum    float64
+}
+
+// expvarMu serializes the check and publication of expvar names, since
+// expvar.Publish panics when a name is reused
+var expvarMu sync.Mutex
+
+// NewExpvarMetrics creates an ExpvarMetrics. If name is not empty, the metrics
+// are published with expvar under that name. It returns an error if the name
+// is already in use
+func NewExpvarMetrics(name string) (*ExpvarMetrics, error) {
+	m := &ExpvarMetrics{
+		buckets:  DefaultLatencyBuckets,
+		requests: make(map[requestLabels]int64),
+		errors:   make(map[errorLabels]int64),
+		latency:  make(map[string]*histogram),
+	}
+	if name == "" {
+		return m, nil
+	}
+
+	expvarMu.Lock()
+	defer expvarMu.Unlock()
+	if expvar.Get(name) != nil {
+		return nil, fmt.Errorf("failed to publish metrics: expvar name %q is already in use", name)
+	}
+	expvar.Publish(name, m)
+	return m, nil
+}
+
+// RecordRequest records a completed operation
+func (m *ExpvarMetrics) RecordRequest(ctx context.Context, r RequestMetrics) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.requests[requestLabels{r.Operation, r.Method, r.PathTemplate}]++
+
+	if r.Err != nil {
+		status := "error"
+		if r.StatusCode != 0 {
+			status = strconv.Itoa(r.StatusCode)
+		}
+		m.errors[errorLabels{r.Operation, status}]++
+	}
+
+	h, ok := m.latency[r.Operation]
+	if !ok {
+		h = &histogram{counts: make([]int64, len(m.buckets)+1)}
+		m.latency[r.Operation] = h
+	}
+	seconds := r.Duration.Seconds()
+	i, _ := slices.BinarySearch(m.buckets, seconds)
+	h.counts[i]++
+	h.count++
+	h.sum += seconds
+}
+
+// String returns the metrics as JSON, as required by expvar.Var
+func (m *ExpvarMetrics) String() string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	type latency struct {
+		Count   int64            `json:"count"`
+		Sum     float64          `json:"sum"`
+		Buckets map[string]int64 `json:"buckets"`
+	}
+	snapshot := struct {
+		Requests map[string]int64   `json:"requests"`
+		Errors   map[string]int64   `json:"errors"`
+		Latency  map[string]latency `json:"latency"`
+	}{
+		Requests: make(map[string]int64, len(m.requests)),
+		Errors:   make(map[string]int64, len(m.errors)),
+		Latency:  make(map[string]latency, len(m.latency)),
+	}
+
+	for labels, count := range m.requests {
+		snapshot.Requests[labels.operation+" "+labels.method+" "+labels.path] = count
+	}
+	for labels, count := range m.errors {
+		snapshot.Errors[labels.operation+" "+labels.status] = count
+	}
+	for operation, h := range m.latency {
+		l := latency{Count: h.count, Sum: h.sum, Buckets: make(map[string]int64, len(h.counts))}
+		var cumulative int64
+		for i, count := range h.counts {
+			cumulative += count
+			l.Buckets[m.bucketLabel(i)] = cumulative
+		}
+		snapshot.Latency[operation] = l
+	}
+
+	data, _ := json.Marshal(snapshot)
+	return string(data)
+}
+
+// ServeHTTP writes the metrics in the Prometheus text exposition format
+func (m *ExpvarMetrics) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	m.WritePrometheus(w)
+}
+
+// WritePrometheus writes the metrics in the Prometheus text exposition format
+func (m *ExpvarMetrics) WritePrometheus(w io.Writer) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var b strings.Builder
+
+	b.WriteString("# HELP tcgcollector_requests_total Requests made to the TCG Collector API.\n")
+	b.WriteString("# TYPE tcgcollector_requests_total counter\n")
+	requests := sortedKeys(m.requests, func(l requestLabels) string { return l.operation + " " + l.method + " " + l.path })
+	for _, labels := range requests {
+		fmt.Fprintf(&b, "tcgcollector_requests_total{operation=%s,method=%s,path=%s} %d\n",
+			promLabel(labels.operation), promLabel(labels.method), promLabel(labels.path), m.requests[labels])
+	}
+
+	b.WriteString("# HELP tcgcollector_request_errors_total Failed requests to the TCG Collector API by status.\n")
+	b.WriteString("# TYPE tcgcollector_request_errors_total counter\n")
+	errs := sortedKeys(m.errors, func(l errorLabels) string { return l.operation + " " + l.status })
+	for _, labels := range errs {
+		fmt.Fprintf(&b, "tcgcollector_request_errors_total{operation=%s,status=%s} %d\n",
+			promLabel(labels.operation), promLabel(labels.status), m.errors[labels])
+	}
+
+	b.WriteString("# HELP tcgcollector_request_duration_seconds Duration of requests to the TCG Collector API.\n")
+	b.WriteString("# TYPE tcgcollector_request_duration_seconds histogram\n")
+	operations := sortedKeys(m.latency, func(operation string) string { return operation })
+	for _, operation := range operations {
+		h := m.latency[operation]
+		label := promLabel(operation)
+		var cumulative int64
+		for i, count := range h.counts {
+			cumulative += count
+			fmt.Fprintf(&b, "tcgcollector_request_duration_seconds_bucket{operation=%s,le=%q} %d\n", label, m.bucketLabel(i), cumulative)
+		}
+		fmt.Fprintf(&b, "tcgcollector_request_duration_seconds_sum{operation=%s} %s\n", label, strconv.FormatFloat(h.sum, 'g', -1, 64))
+		fmt.Fprintf(&b, "tcgcollector_request_duration_seconds_count{operation=%s} %d\n", label, h.count)
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// bucketLabel returns the upper bound of the i-th histogram bucket
+func (m *ExpvarMetrics) bucketLabel(i int) string {
+	if i == len(m.buckets) {
+		return "+Inf"
+	}
+	return strconv.FormatFloat(m.buckets[i], 'g', -1, 64)
+}
+
+// sortedKeys returns the keys of m ordered by the string returned by sortKey
+func sortedKeys[K comparable, V any](m map[K]V, sortKey func(K) string) []K {
+	keys := make([]K, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	slices.SortFunc(keys, func(a, b K) int { return strings.Compare(sortKey(a), sortKey(b)) })
+	return keys
+}
+
+// promLabel quotes a Prometheus label value
+func promLabel(value string) string {
+	value = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(value)
+	return `"` + value + `"`
+}
@@ -0,0 +1,123 @@
+package tcgcollector
+
+import (
+	"context"
+	"encoding/json"
+	"expvar"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// recordingMetrics is a MetricsRecorder that keeps every recorded operation
+type recordingMetrics struct {
+	mu      sync.Mutex
+	records []RequestMetrics
+}
+
+func (r *recordingMetrics) RecordRequest(ctx context.Context, m RequestMetrics) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.records = append(r.records, m)
+}
+
+func TestMetricsRecorder(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/cards/2" {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"message": "Card not found", "code": "NOT_FOUND"}`))
+			return
+		}
+		w.Write([]byte(`{"id": 1}`))
+	}))
+	defer ts.Close()
+
+	recorder := &recordingMetrics{}
+	client := NewClient("test-api-key", WithBaseURL(ts.URL), WithMetrics(recorder))
+	_, err := client.GetCard(context.Background(), 1)
+	assert.NoError(t, err)
+	_, err = client.GetCard(context.Background(), 2)
+	assert.Error(t, err)
+
+	assert.Len(t, recorder.records, 2)
+	assert.Equal(t, "GetCard", recorder.records[0].Operation)
+	assert.Equal(t, http.MethodGet, recorder.records[0].Method)
+	assert.Equal(t, "/api/cards/{id}", recorder.records[0].PathTemplate)
+	assert.Equal(t, http.StatusOK, recorder.records[0].StatusCode)
+	assert.Positive(t, recorder.records[0].Duration)
+	assert.NoError(t, recorder.records[0].Err)
+
+	assert.Equal(t, http.StatusNotFound, recorder.records[1].StatusCode)
+	assert.True(t, IsNotFound(recorder.records[1].Err))
+}
+
+func TestExpvarMetricsPrometheus(t *testing.T) {
+	m, err := NewExpvarMetrics("")
+	assert.NoError(t, err)
+	ctx := context.Background()
+	m.RecordRequest(ctx, RequestMetrics{Operation: "GetCard", Method: "GET", PathTemplate: "/api/cards/{id}", StatusCode: 200, Duration: 20 * time.Millisecond})
+	m.RecordRequest(ctx, RequestMetrics{Operation: "GetCard", Method: "GET", PathTemplate: "/api/cards/{id}", StatusCode: 404, Duration: 3 * time.Second, Err: &APIError{StatusCode: 404}})
+	m.RecordRequest(ctx, RequestMetrics{Operation: "ListSets", Method: "GET", PathTemplate: "/api/sets", Duration: time.Minute, Err: context.DeadlineExceeded})
+
+	rec := httptest.NewRecorder()
+	m.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	assert.Equal(t, "text/plain; version=0.0.4; charset=utf-8", rec.Header().Get("Content-Type"))
+
+	body := rec.Body.String()
+	for _, line := range []string{
+		"# TYPE tcgcollector_requests_total counter",
+		`tcgcollector_requests_total{operation="GetCard",method="GET",path="/api/cards/{id}"} 2`,
+		`tcgcollector_requests_total{operation="ListSets",method="GET",path="/api/sets"} 1`,
+		`tcgcollector_request_errors_total{operation="GetCard",status="404"} 1`,
+		`tcgcollector_request_errors_total{operation="ListSets",status="error"} 1`,
+		"# TYPE tcgcollector_request_duration_seconds histogram",
+		`tcgcollector_request_duration_seconds_bucket{operation="GetCard",le="0.01"} 0`,
+		`tcgcollector_request_duration_seconds_bucket{operation="GetCard",le="0.025"} 1`,
+		`tcgcollector_request_duration_seconds_bucket{operation="GetCard",le="5"} 2`,
+		`tcgcollector_request_duration_seconds_bucket{operation="ListSets",le="30"} 0`,
+		`tcgcollector_request_duration_seconds_bucket{operation="ListSets",le="+Inf"} 1`,
+		`tcgcollector_request_duration_seconds_sum{operation="GetCard"} 3.02`,
+		`tcgcollector_request_duration_seconds_count{operation="GetCard"} 2`,
+	} {
+		assert.Contains(t, strings.Split(body, "\n"), line)
+	}
+}
+
+// expvarNames numbers the expvar names of tests, which stay published for
+// the lifetime of the test binary
+var expvarNames atomic.Int32
+
+func TestExpvarMetricsPublished(t *testing.T) {
+	name := fmt.Sprintf("tcgcollector_test_metrics_%d", expvarNames.Add(1))
+	m, err := NewExpvarMetrics(name)
+	if !assert.NoError(t, err) {
+		return
+	}
+	m.RecordRequest(context.Background(), RequestMetrics{Operation: "GetCard", Method: "GET", PathTemplate: "/api/cards/{id}", StatusCode: 500, Err: &APIError{StatusCode: 500}})
+	assert.Same(t, m, expvar.Get(name))
+
+	// Reusing the name fails instead of panicking
+	_, err = NewExpvarMetrics(name)
+	assert.EqualError(t, err, fmt.Sprintf("failed to publish metrics: expvar name %q is already in use", name))
+
+	var snapshot struct {
+		Requests map[string]int64 `json:"requests"`
+		Errors   map[string]int64 `json:"errors"`
+		Latency  map[string]struct {
+			Count   int64            `json:"count"`
+			Buckets map[string]int64 `json:"buckets"`
+		} `json:"latency"`
+	}
+	assert.NoError(t, json.Unmarshal([]byte(m.String()), &snapshot))
+	assert.Equal(t, int64(1), snapshot.Requests["GetCard GET /api/cards/{id}"])
+	assert.Equal(t, int64(1), snapshot.Errors["GetCard 500"])
+	assert.Equal(t, int64(1), snapshot.Latency["GetCard"].Count)
+	assert.Equal(t, int64(1), snapshot.Latency["GetCard"].Buckets["+Inf"])
+}
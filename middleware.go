@@ -0,0 +1,92 @@
+package tcgcollector
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// Operation describes the SDK operation that a request belongs to
+type Operation struct {
+	// Name is the name of the client method, e.g. "ListCards"
+	Name string
+	// Method is the HTTP method
+	Method string
+	// PathTemplate is the request path with IDs replaced by placeholders, e.g. "/api/cards/{id}"
+	PathTemplate string
+	// Path is the request path including the query string
+	Path string
+	// Body is the request body before it is encoded as JSON
+	Body interface{}
+	// Header contains additional headers sent with the request
+	Header http.Header
+
+	// openBody returns the request body of an attempt and its content type,
+	// replacing the JSON encoded Body
+	openBody func() (io.Reader, string, error)
+	// stream consumes the body and headers of a successful response instead
+	// of buffering it
+	stream func(r io.Reader, header http.Header) error
+	// download marks the request for a public file rather than an API
+	// resource. It is sent without a token and its body need not be JSON
+	download bool
+}
+
+// Response is the buffered response of an operation
+type Response struct {
+	StatusCode int
+	Header     http.Header
+	Body       []byte
+
+	// attempts is the number of requests sent for the response
+	attempts int
+}
+
+// Handler performs an operation. It returns the response together with the
+// error when the API responded with an error status code
+type Handler func(ctx context.Context, op *Operation) (*Response, error)
+
+// Middleware wraps a Handler to run code before and after an operation
+type Middleware func(next Handler) Handler
+
+// WithMiddleware adds middleware around every operation performed by the client.
+// Middleware added first runs outermost
+func WithMiddleware(middleware ...Middleware) ClientOption {
+	return func(c *Client) {
+		c.middleware = append(c.middleware, middleware...)
+	}
+}
+
+// handler returns the client's middleware chain around the given handler
+func (c *Client) handler(final Handler) Handler {
+	h := final
+	for i := len(c.middleware) - 1; i >= 0; i-- {
+		h = c.middleware[i](h)
+	}
+	return h
+}
+
+// newOperation creates the descriptor of the operation performed by the client method name
+func newOperation(name, method, path string, body interface{}) *Operation {
+	return &Operation{
+		Name:         name,
+		Method:       method,
+		PathTemplate: pathTemplate(path),
+		Path:         path,
+		Body:         body,
+		Header:       make(http.Header),
+	}
+}
+
+// pathTemplate strips the query string from path and replaces numeric segments with "{id}"
+func pathTemplate(path string) string {
+	path, _, _ = strings.Cut(path, "?")
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		if segment != "" && strings.Trim(segment, "0123456789") == "" {
+			segments[i] = "{id}"
+		}
+	}
+	return strings.Join(segments, "/")
+}
@@ -0,0 +1,166 @@
+package tcgcollector
+
+import (
+	"context"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithMiddleware(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "injected", r.Header.Get("X-Custom"))
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id": 1, "name": "Test Card"}`))
+	}))
+	defer ts.Close()
+
+	var calls []string
+	var seen Operation
+	var status int
+	recorder := func(name string) Middleware {
+		return func(next Handler) Handler {
+			return func(ctx context.Context, op *Operation) (*Response, error) {
+				calls = append(calls, name+" before")
+				resp, err := next(ctx, op)
+				calls = append(calls, name+" after")
+				return resp, err
+			}
+		}
+	}
+	inspector := func(next Handler) Handler {
+		return func(ctx context.Context, op *Operation) (*Response, error) {
+			op.Header.Set("X-Custom", "injected")
+			seen = *op
+			resp, err := next(ctx, op)
+			status = resp.StatusCode
+			return resp, err
+		}
+	}
+
+	client := NewClient("test-api-key", WithBaseURL(ts.URL), WithMiddleware(recorder("outer"), recorder("inner")), WithMiddleware(inspector))
+	card, err := client.GetCard(context.Background(), 1)
+	assert.NoError(t, err)
+	assert.Equal(t, "Test Card", card.Name)
+
+	assert.Equal(t, []string{"outer before", "inner before", "inner after", "outer after"}, calls)
+	assert.Equal(t, "GetCard", seen.Name)
+	assert.Equal(t, http.MethodGet, seen.Method)
+	assert.Equal(t, "/api/cards/{id}", seen.PathTemplate)
+	assert.Equal(t, "/api/cards/1", seen.Path)
+	assert.Equal(t, http.StatusOK, status)
+}
+
+func TestMiddlewareSeesRequestBodyAndError(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"message": "Collection not found", "code": "NOT_FOUND"}`))
+	}))
+	defer ts.Close()
+
+	var seen *Operation
+	var seenResp *Response
+	var seenErr error
+	client := NewClient("test-api-key", WithBaseURL(ts.URL), WithMiddleware(func(next Handler) Handler {
+		return func(ctx context.Context, op *Operation) (*Response, error) {
+			seen = op
+			seenResp, seenErr = next(ctx, op)
+			return seenResp, seenErr
+		}
+	}))
+
+	collection := &CollectionCard{CardID: 5, Quantity: 2}
+	_, err := client.UpdateCollectionCard(context.Background(), 1, 5, collection)
+	assert.True(t, IsNotFound(err))
+
+	assert.Equal(t, "UpdateCollectionCard", seen.Name)
+	assert.Equal(t, "/api/collections/{id}/cards/{id}", seen.PathTemplate)
+	assert.Same(t, collection, seen.Body)
+	assert.Equal(t, http.StatusNotFound, seenResp.StatusCode)
+	assert.True(t, IsNotFound(seenErr))
+}
+
+func TestMiddlewareShortCircuit(t *testing.T) {
+	client := NewClient("test-api-key", WithHTTPClient(&http.Client{Transport: &errorRoundTripper{}}), WithMiddleware(func(next Handler) Handler {
+		return func(ctx context.Context, op *Operation) (*Response, error) {
+			return &Response{StatusCode: http.StatusOK, Body: []byte(`{"status": "ok"}`)}, nil
+		}
+	}))
+
+	health, err := client.GetHealth(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, "ok", health.Status)
+}
+
+func TestNewOperation(t *testing.T) {
+	op := newOperation("ListCards", http.MethodGet, "/api/cards?page=2", nil)
+	assert.Equal(t, "ListCards", op.Name)
+	assert.Equal(t, "/api/cards", op.PathTemplate)
+	assert.NotNil(t, op.Header)
+}
+
+// TestOperationNamesMatchMethods checks that every client method names its
+// operation after itself
+func TestOperationNamesMatchMethods(t *testing.T) {
+	fset := token.NewFileSet()
+	files, err := filepath.Glob("*.go")
+	assert.NoError(t, err)
+
+	calls := 0
+	for _, file := range files {
+		if strings.HasSuffix(file, "_test.go") {
+			continue
+		}
+		f, err := parser.ParseFile(fset, file, nil, 0)
+		if !assert.NoError(t, err) {
+			continue
+		}
+		for _, decl := range f.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok || fn.Recv == nil || fn.Body == nil || !fn.Name.IsExported() {
+				continue
+			}
+			ast.Inspect(fn.Body, func(n ast.Node) bool {
+				call, ok := n.(*ast.CallExpr)
+				if !ok {
+					return true
+				}
+				var callee string
+				var nameArg int
+				switch fun := call.Fun.(type) {
+				case *ast.SelectorExpr:
+					callee, nameArg = fun.Sel.Name, 1
+				case *ast.Ident:
+					callee, nameArg = fun.Name, 0
+				}
+				switch callee {
+				case "doRequest", "doIdempotentRequest", "newOperation":
+				default:
+					return true
+				}
+				calls++
+				lit, ok := call.Args[nameArg].(*ast.BasicLit)
+				if assert.True(t, ok, "%s: %s must pass its name as a literal", fset.Position(call.Pos()), fn.Name.Name) {
+					assert.Equal(t, strconv.Quote(fn.Name.Name), lit.Value, fset.Position(call.Pos()).String())
+				}
+				return true
+			})
+		}
+	}
+	assert.Greater(t, calls, 100)
+}
+
+func TestPathTemplate(t *testing.T) {
+	assert.Equal(t, "/api/cards", pathTemplate("/api/cards?setId=12"))
+	assert.Equal(t, "/api/cards/{id}/prices", pathTemplate("/api/cards/12/prices"))
+	assert.Equal(t, "/api/users/me", pathTemplate("/api/users/me"))
+	assert.Equal(t, "/api/collections/{id}/cards/{id}", pathTemplate("/api/collections/1/cards/22"))
+}
@@ -14,8 +14,9 @@ type ListResponse[T any] struct {
 }
 
 type ErrorResponse struct {
-	Message string `json:"message"`
-	Code    string `json:"code"`
+	Message    string            `json:"message"`
+	Code       string            `json:"code"`
+	Violations []ValidationError `json:"violations,omitempty"`
 }
 
 // Audit Log types
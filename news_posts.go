@@ -3,6 +3,7 @@ package tcgcollector
 import (
 	"context"
 	"fmt"
+	"iter"
 	"net/http"
 	"net/url"
 	"strconv"
@@ -18,6 +19,40 @@ type ListNewsPostsParams struct {
 type ListNewsPostsResponse struct {
 	Items []NewsPost `json:"items"`
 	Total int        `json:"total"`
+
+	// The API does not echo the requested page, so it is kept for NextParams
+	page     int
+	pageSize int
+}
+
+// PageItems returns the news posts on the page
+func (r *ListNewsPostsResponse) PageItems() []NewsPost {
+	return r.Items
+}
+
+// PageInfo returns the position of the page and the total number of news posts
+func (r *ListNewsPostsResponse) PageInfo() PageInfo {
+	info := PageInfo{Page: r.page, ItemCount: len(r.Items), TotalItemCount: r.Total}
+	if r.pageSize > 0 && r.Total > 0 {
+		info.PageCount = (r.Total + r.pageSize - 1) / r.pageSize
+	}
+	return info
+}
+
+// NextParams returns the parameters of the next page, or nil if this is the last page
+func (r *ListNewsPostsResponse) NextParams() *PageParams {
+	if len(r.Items) == 0 {
+		return nil
+	}
+
+	pageSize := r.pageSize
+	if pageSize <= 0 {
+		pageSize = len(r.Items)
+	}
+	if r.Total > 0 && (r.page-1)*pageSize+len(r.Items) >= r.Total {
+		return nil
+	}
+	return &PageParams{Page: r.page + 1}
 }
 
 // ListNewsPosts retrieves a list of news posts with optional pagination
@@ -36,18 +71,39 @@ func (c *Client) ListNewsPosts(ctx context.Context, params *ListNewsPostsParams)
 		}
 	}
 
-	var result ListNewsPostsResponse
-	err := c.doRequest(ctx, http.MethodGet, path, nil, &result)
+	result := ListNewsPostsResponse{page: 1}
+	if params != nil {
+		result.page = max(params.Page, 1)
+		result.pageSize = params.PageSize
+	}
+	err := c.doRequest(ctx, "ListNewsPosts", http.MethodGet, path, nil, &result)
 	if err != nil {
 		return nil, err
 	}
 	return &result, nil
 }
 
+// AllNewsPosts iterates over all news posts, fetching pages as needed
+func (c *Client) AllNewsPosts(ctx context.Context, params *ListNewsPostsParams, opts ...PageOption) iter.Seq2[NewsPost, error] {
+	var p ListNewsPostsParams
+	if params != nil {
+		p = *params
+	}
+	var startPage *int
+	if p.Page > 0 {
+		startPage = &p.Page
+	}
+	return paginate(ctx, startPage, func(ctx context.Context, page int) (Page[NewsPost], error) {
+		pageParams := p
+		pageParams.Page = page
+		return c.ListNewsPosts(ctx, &pageParams)
+	}, opts...)
+}
+
 // GetNewsPost retrieves a specific news post by ID
 func (c *Client) GetNewsPost(ctx context.Context, id int) (*NewsPost, error) {
 	var result NewsPost
-	err := c.doRequest(ctx, http.MethodGet, fmt.Sprintf("/api/news-posts/%d", id), nil, &result)
+	err := c.doRequest(ctx, "GetNewsPost", http.MethodGet, fmt.Sprintf("/api/news-posts/%d", id), nil, &result)
 	if err != nil {
 		return nil, err
 	}
@@ -63,7 +119,7 @@ type CreateNewsPostRequest struct {
 // CreateNewsPost creates a new news post
 func (c *Client) CreateNewsPost(ctx context.Context, request *CreateNewsPostRequest) (*NewsPost, error) {
 	var result NewsPost
-	err := c.doRequest(ctx, http.MethodPost, "/api/news-posts", request, &result)
+	err := c.doIdempotentRequest(ctx, "CreateNewsPost", http.MethodPost, "/api/news-posts", request, &result)
 	if err != nil {
 		return nil, err
 	}
@@ -79,7 +135,7 @@ type UpdateNewsPostRequest struct {
 // UpdateNewsPost updates an existing news post
 func (c *Client) UpdateNewsPost(ctx context.Context, id int, request *UpdateNewsPostRequest) (*NewsPost, error) {
 	var result NewsPost
-	err := c.doRequest(ctx, http.MethodPut, fmt.Sprintf("/api/news-posts/%d", id), request, &result)
+	err := c.doRequest(ctx, "UpdateNewsPost", http.MethodPut, fmt.Sprintf("/api/news-posts/%d", id), request, &result)
 	if err != nil {
 		return nil, err
 	}
@@ -88,5 +144,5 @@ func (c *Client) UpdateNewsPost(ctx context.Context, id int, request *UpdateNews
 
 // DeleteNewsPost deletes a news post by ID
 func (c *Client) DeleteNewsPost(ctx context.Context, id int) error {
-	return c.doRequest(ctx, http.MethodDelete, fmt.Sprintf("/api/news-posts/%d", id), nil, nil)
+	return c.doRequest(ctx, "DeleteNewsPost", http.MethodDelete, fmt.Sprintf("/api/news-posts/%d", id), nil, nil)
 }
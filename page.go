@@ -0,0 +1,83 @@
+package tcgcollector
+
+// Page is a single page of a paginated list. It is implemented by the
+// responses of all paginated list methods, so code that handles pages can
+// work across resources
+type Page[T any] interface {
+	// PageItems returns the items on the page
+	PageItems() []T
+
+	// PageInfo returns the position of the page and the totals of the list
+	PageInfo() PageInfo
+
+	// NextParams returns the parameters of the next page, or nil if this is
+	// the last page or its position is unknown
+	NextParams() *PageParams
+}
+
+// PageInfo describes a page of a paginated list. Values the API does not
+// report are zero
+type PageInfo struct {
+	Page           int
+	PageCount      int
+	ItemCount      int
+	TotalItemCount int
+}
+
+// PageParams selects a page of a paginated list. Copy it into the Page field
+// of the list method's params to fetch that page
+type PageParams struct {
+	Page int
+}
+
+// PageItems returns the items on the page
+func (r *ListResponse[T]) PageItems() []T {
+	return r.Items
+}
+
+// PageInfo returns the position of the page and the totals of the list
+func (r *ListResponse[T]) PageInfo() PageInfo {
+	return PageInfo{
+		Page:           r.Page,
+		PageCount:      r.PageCount,
+		ItemCount:      r.ItemCount,
+		TotalItemCount: r.TotalItemCount,
+	}
+}
+
+// NextParams returns the parameters of the next page, or nil if this is the last page
+func (r *ListResponse[T]) NextParams() *PageParams {
+	switch {
+	case len(r.Items) == 0 || r.Page <= 0:
+		return nil
+	case r.PageCount > 0:
+		if r.Page >= r.PageCount {
+			return nil
+		}
+	case r.TotalItemCount > 0:
+		// Every page but the last is full, so the items so far are at most page * len(items)
+		if r.Page*len(r.Items) >= r.TotalItemCount {
+			return nil
+		}
+	}
+	return &PageParams{Page: r.Page + 1}
+}
+
+// SlicePage adapts the result of a list method that returns all items at once,
+// such as ListExpansions, to a Page with no further pages
+type SlicePage[T any] []T
+
+// PageItems returns the items
+func (p SlicePage[T]) PageItems() []T {
+	return p
+}
+
+// PageInfo returns the totals of the single page
+func (p SlicePage[T]) PageInfo() PageInfo {
+	return PageInfo{Page: 1, PageCount: 1, ItemCount: len(p), TotalItemCount: len(p)}
+}
+
+// NextParams returns nil because there are no further pages
+func (p SlicePage[T]) NextParams() *PageParams {
+	return nil
+}
@@ -0,0 +1,69 @@
+package tcgcollector
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Every paginated list response implements Page
+var (
+	_ Page[User]            = (*ListUsersResponse)(nil)
+	_ Page[Image]           = (*ListImagesResponse)(nil)
+	_ Page[EntityType]      = (*ListEntityTypesResponse)(nil)
+	_ Page[CardVariantType] = (*ListCardVariantTypesResponse)(nil)
+	_ Page[NewsPost]        = (*ListNewsPostsResponse)(nil)
+	_ Page[Expansion]       = SlicePage[Expansion](nil)
+)
+
+func TestListResponseNextParams(t *testing.T) {
+	tests := []struct {
+		name string
+		resp ListResponse[int]
+		want *PageParams
+	}{
+		{"page count", ListResponse[int]{Items: []int{1}, Page: 1, PageCount: 2}, &PageParams{Page: 2}},
+		{"last page", ListResponse[int]{Items: []int{1}, Page: 2, PageCount: 2}, nil},
+		{"total item count", ListResponse[int]{Items: []int{1, 2}, Page: 1, TotalItemCount: 3}, &PageParams{Page: 2}},
+		{"total item count reached", ListResponse[int]{Items: []int{1, 2}, Page: 2, TotalItemCount: 3}, nil},
+		{"no totals", ListResponse[int]{Items: []int{1}, Page: 4}, &PageParams{Page: 5}},
+		{"empty page", ListResponse[int]{Page: 1, PageCount: 2}, nil},
+		{"unknown page", ListResponse[int]{Items: []int{1}, PageCount: 2}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.resp.NextParams())
+		})
+	}
+}
+
+func TestListNewsPostsPage(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"items": [{"id": 3}, {"id": 4}], "total": 5}`))
+	}))
+	defer ts.Close()
+
+	client := NewClient("test-api-key", WithBaseURL(ts.URL))
+	resp, err := client.ListNewsPosts(context.Background(), &ListNewsPostsParams{Page: 2, PageSize: 2})
+	assert.NoError(t, err)
+
+	// The API only reports the total, so the position comes from the request
+	var page Page[NewsPost] = resp
+	assert.Len(t, page.PageItems(), 2)
+	assert.Equal(t, PageInfo{Page: 2, PageCount: 3, ItemCount: 2, TotalItemCount: 5}, page.PageInfo())
+	assert.Equal(t, &PageParams{Page: 3}, page.NextParams())
+
+	resp.page = 3
+	assert.Nil(t, resp.NextParams())
+}
+
+func TestSlicePage(t *testing.T) {
+	page := SlicePage[string]{"a", "b"}
+	assert.Equal(t, []string{"a", "b"}, page.PageItems())
+	assert.Equal(t, PageInfo{Page: 1, PageCount: 1, ItemCount: 2, TotalItemCount: 2}, page.PageInfo())
+	assert.Nil(t, page.NextParams())
+}
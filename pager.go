@@ -0,0 +1,188 @@
+package tcgcollector
+
+import (
+	"context"
+	"iter"
+)
+
+// pageFunc fetches a single page of a paginated list
+type pageFunc[T any] func(ctx context.Context, page int) (Page[T], error)
+
+// PageOption configures how a paginated list is iterated
+type PageOption func(*pageOptions)
+
+type pageOptions struct {
+	prefetch int
+}
+
+// WithPrefetch fetches up to workers pages concurrently once the first page
+// reveals the page count. Items are still yielded in page order, and
+// outstanding fetches are canceled on the first error or when iteration stops
+func WithPrefetch(workers int) PageOption {
+	return func(o *pageOptions) {
+		o.prefetch = workers
+	}
+}
+
+// paginate returns an iterator over the items of all pages, starting at
+// startPage or the first page if it is nil. Iteration stops at the first
+// error, which is yielded together with the zero value of T
+func paginate[T any](ctx context.Context, startPage *int, fetch pageFunc[T], opts ...PageOption) iter.Seq2[T, error] {
+	var options pageOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	return func(yield func(T, error) bool) {
+		var zero T
+		page := 1
+		if startPage != nil {
+			page = *startPage
+		}
+
+		seen := 0
+		for {
+			if err := ctx.Err(); err != nil {
+				yield(zero, err)
+				return
+			}
+
+			resp, err := fetch(ctx, page)
+			if err != nil {
+				yield(zero, err)
+				return
+			}
+
+			items := resp.PageItems()
+			if !yieldItems(ctx, items, yield) {
+				return
+			}
+
+			seen += len(items)
+			if isLastPage(resp, page, seen) {
+				return
+			}
+
+			// The remaining pages are known once the page count is, so fetch them concurrently
+			if info := resp.PageInfo(); options.prefetch > 1 && info.PageCount > 0 {
+				prefetch(ctx, page+1, info.PageCount, options.prefetch, fetch, yield)
+				return
+			}
+			page++
+		}
+	}
+}
+
+// isLastPage reports whether resp, the requested page of a paginated list,
+// is its last page. The requested page is used rather than the one the API
+// reports, which may be missing
+func isLastPage[T any](resp Page[T], page, seen int) bool {
+	info := resp.PageInfo()
+	switch {
+	case len(resp.PageItems()) == 0:
+		return true
+	case info.Page > 0 && resp.NextParams() == nil:
+		return true
+	case info.PageCount > 0:
+		return page >= info.PageCount
+	case info.TotalItemCount > 0:
+		return seen >= info.TotalItemCount
+	}
+	return false
+}
+
+// pageResult is the outcome of fetching a single page
+type pageResult[T any] struct {
+	resp Page[T]
+	err  error
+}
+
+// prefetch fetches pages first through last with up to workers concurrent
+// requests and yields their items in page order
+func prefetch[T any](ctx context.Context, first, last, workers int, fetch pageFunc[T], yield func(T, error) bool) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// Each page has its own buffered channel so that fetches never block, and
+	// the semaphore bounds pages that are being fetched or waiting to be yielded
+	results := make([]chan pageResult[T], last-first+1)
+	for i := range results {
+		results[i] = make(chan pageResult[T], 1)
+	}
+	sem := make(chan struct{}, workers)
+
+	go func() {
+		for i := range results {
+			select {
+			case sem <- struct{}{}:
+			case <-ctx.Done():
+				return
+			}
+			go func(i int) {
+				resp, err := fetch(ctx, first+i)
+				results[i] <- pageResult[T]{resp: resp, err: err}
+			}(i)
+		}
+	}()
+
+	var zero T
+	for i := range results {
+		var result pageResult[T]
+		select {
+		case result = <-results[i]:
+		case <-ctx.Done():
+			yield(zero, ctx.Err())
+			return
+		}
+		<-sem
+
+		if result.err != nil {
+			yield(zero, result.err)
+			return
+		}
+		items := result.resp.PageItems()
+		if !yieldItems(ctx, items, yield) {
+			return
+		}
+		if len(items) == 0 {
+			return
+		}
+	}
+}
+
+// yieldItems yields each item and reports whether iteration should continue
+func yieldItems[T any](ctx context.Context, items []T, yield func(T, error) bool) bool {
+	var zero T
+	for _, item := range items {
+		if err := ctx.Err(); err != nil {
+			yield(zero, err)
+			return false
+		}
+		if !yield(item, nil) {
+			return false
+		}
+	}
+	return true
+}
+
+// Collect gathers all items of an iterator into a slice. It returns the items
+// collected so far together with the first error
+func Collect[T any](seq iter.Seq2[T, error]) ([]T, error) {
+	return CollectN(seq, 0)
+}
+
+// CollectN gathers at most maxItems items of an iterator into a slice, or all
+// items if maxItems is not positive. Pages after the last needed one are not fetched
+func CollectN[T any](seq iter.Seq2[T, error], maxItems int) ([]T, error) {
+	var items []T
+	for item, err := range seq {
+		if err != nil {
+			return items, err
+		}
+		items = append(items, item)
+		if maxItems > 0 && len(items) >= maxItems {
+			break
+		}
+	}
+	return items, nil
+}
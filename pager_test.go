@@ -0,0 +1,345 @@
+package tcgcollector
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"iter"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// newPagedServer returns a test server that serves total items with IDs 1..total
+// from path, split into pages of pageSize items
+func newPagedServer(t *testing.T, path string, total, pageSize int) (*httptest.Server, *int32) {
+	var requests int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		if r.URL.Path != path {
+			t.Errorf("Expected path %s, got %s", path, r.URL.Path)
+		}
+
+		page, err := strconv.Atoi(r.URL.Query().Get("page"))
+		assert.NoError(t, err)
+
+		items := []map[string]int{}
+		for id := (page-1)*pageSize + 1; id <= page*pageSize && id <= total; id++ {
+			items = append(items, map[string]int{"id": id})
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"items":          items,
+			"itemCount":      len(items),
+			"totalItemCount": total,
+			"total":          total,
+			"page":           page,
+			"pageCount":      (total + pageSize - 1) / pageSize,
+		})
+	}))
+	return ts, &requests
+}
+
+// collectIDs iterates over seq and returns the ID of every item
+func collectIDs[T any](seq iter.Seq2[T, error], id func(T) int) ([]int, error) {
+	var ids []int
+	for item, err := range seq {
+		if err != nil {
+			return ids, err
+		}
+		ids = append(ids, id(item))
+	}
+	return ids, nil
+}
+
+func TestAllIterators(t *testing.T) {
+	ctx := context.Background()
+	tests := []struct {
+		path string
+		run  func(c *Client) ([]int, error)
+	}{
+		{"/api/audit-log", func(c *Client) ([]int, error) {
+			return collectIDs(c.AllAuditLogEntries(ctx, nil), func(v AuditLogEntry) int { return v.ID })
+		}},
+		{"/api/card-database-logs", func(c *Client) ([]int, error) {
+			return collectIDs(c.AllCardDatabaseLogs(ctx, nil), func(v CardDatabaseLog) int { return v.ID })
+		}},
+		{"/api/card-grades", func(c *Client) ([]int, error) {
+			return collectIDs(c.AllCardGrades(ctx, nil), func(v CardGrade) int { return v.ID })
+		}},
+		{"/api/card-list-prices", func(c *Client) ([]int, error) {
+			return collectIDs(c.AllCardListPrices(ctx, nil), func(v CardListPrice) int { return v.ID })
+		}},
+		{"/api/card-list-references", func(c *Client) ([]int, error) {
+			return collectIDs(c.AllCardListReferences(ctx, nil), func(v CardListReference) int { return v.ID })
+		}},
+		{"/api/card-references", func(c *Client) ([]int, error) {
+			return collectIDs(c.AllCardReferences(ctx, nil), func(v CardReference) int { return v.ID })
+		}},
+		{"/api/card-variant-references", func(c *Client) ([]int, error) {
+			return collectIDs(c.AllCardVariantReferences(ctx, nil), func(v CardVariantReference) int { return v.ID })
+		}},
+		{"/api/card-variant-types", func(c *Client) ([]int, error) {
+			return collectIDs(c.AllCardVariantTypes(ctx, nil), func(v CardVariantType) int { return v.ID })
+		}},
+		{"/api/card-variants", func(c *Client) ([]int, error) {
+			return collectIDs(c.AllCardVariants(ctx, nil), func(v CardVariant) int { return v.ID })
+		}},
+		{"/api/cards", func(c *Client) ([]int, error) {
+			return collectIDs(c.AllCards(ctx, nil), func(v Card) int { return v.ID })
+		}},
+		{"/api/collections", func(c *Client) ([]int, error) {
+			return collectIDs(c.AllCollections(ctx, nil), func(v Collection) int { return v.ID })
+		}},
+		{"/api/entity-types", func(c *Client) ([]int, error) {
+			return collectIDs(c.AllEntityTypes(ctx, nil), func(v EntityType) int { return v.ID })
+		}},
+		{"/api/expansion-prices", func(c *Client) ([]int, error) {
+			return collectIDs(c.AllExpansionPrices(ctx, nil), func(v ExpansionPrice) int { return v.ID })
+		}},
+		{"/api/expansion-references", func(c *Client) ([]int, error) {
+			return collectIDs(c.AllExpansionReferences(ctx, nil), func(v ExpansionReference) int { return v.ID })
+		}},
+		{"/api/expansion-series", func(c *Client) ([]int, error) {
+			return collectIDs(c.AllExpansionSeries(ctx, nil), func(v ExpansionSeries) int { return v.ID })
+		}},
+		{"/api/images", func(c *Client) ([]int, error) {
+			return collectIDs(c.AllImages(ctx, nil), func(v Image) int { return v.ID })
+		}},
+		{"/api/news-posts", func(c *Client) ([]int, error) {
+			return collectIDs(c.AllNewsPosts(ctx, nil), func(v NewsPost) int { return v.ID })
+		}},
+		{"/api/pokemon-stages", func(c *Client) ([]int, error) {
+			return collectIDs(c.AllPokemonStages(ctx, nil), func(v PokemonStage) int { return v.ID })
+		}},
+		{"/api/regulation-marks", func(c *Client) ([]int, error) {
+			return collectIDs(c.AllRegulationMarks(ctx, nil), func(v RegulationMark) int { return v.ID })
+		}},
+		{"/api/sets", func(c *Client) ([]int, error) {
+			return collectIDs(c.AllSets(ctx, nil), func(v Set) int { return v.ID })
+		}},
+		{"/api/tcg-price-sources", func(c *Client) ([]int, error) {
+			return collectIDs(c.AllTCGPriceSources(ctx, nil), func(v TCGPriceSource) int { return v.ID })
+		}},
+		{"/api/tcg-regions", func(c *Client) ([]int, error) {
+			return collectIDs(c.AllTCGRegions(ctx, nil), func(v TCGRegion) int { return v.ID })
+		}},
+		{"/api/users", func(c *Client) ([]int, error) {
+			return collectIDs(c.AllUsers(ctx, nil), func(v User) int { return v.ID })
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			ts, requests := newPagedServer(t, tt.path, 5, 2)
+			defer ts.Close()
+
+			client := NewClient("test-api-key", WithBaseURL(ts.URL))
+			ids, err := tt.run(client)
+			assert.NoError(t, err)
+			assert.Equal(t, []int{1, 2, 3, 4, 5}, ids)
+			assert.Equal(t, int32(3), atomic.LoadInt32(requests))
+		})
+	}
+}
+
+func TestAllCardsKeepsFilters(t *testing.T) {
+	var pages []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "12", r.URL.Query().Get("setId"))
+		assert.Equal(t, "50", r.URL.Query().Get("pageSize"))
+		pages = append(pages, r.URL.Query().Get("page"))
+		w.Write([]byte(`{"items": [{"id": 1}], "page": 3, "pageCount": 4}`))
+	}))
+	defer ts.Close()
+
+	client := NewClient("test-api-key", WithBaseURL(ts.URL))
+	setID, page, pageSize := 12, 3, 50
+	params := &ListCardsParams{SetID: &setID, Page: &page, PageSize: &pageSize}
+
+	cards, err := Collect(client.AllCards(context.Background(), params))
+	assert.NoError(t, err)
+	assert.Len(t, cards, 2)
+	assert.Equal(t, []string{"3", "4"}, pages)
+
+	// The caller's params are not modified
+	assert.Equal(t, 3, *params.Page)
+}
+
+func TestAllCardsWithoutPageInResponse(t *testing.T) {
+	// The API may omit the page number, so the iterator counts pages itself
+	var pages []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		page := r.URL.Query().Get("page")
+		pages = append(pages, page)
+		w.Write([]byte(`{"items": [{"id": ` + page + `}], "pageCount": 3, "totalItemCount": 3}`))
+	}))
+	defer ts.Close()
+
+	client := NewClient("test-api-key", WithBaseURL(ts.URL))
+	ids, err := collectIDs(client.AllCards(context.Background(), nil), func(c Card) int { return c.ID })
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1, 2, 3}, ids)
+	assert.Equal(t, []string{"1", "2", "3"}, pages)
+}
+
+func TestCollectN(t *testing.T) {
+	ts, requests := newPagedServer(t, "/api/cards", 10, 3)
+	defer ts.Close()
+
+	client := NewClient("test-api-key", WithBaseURL(ts.URL))
+	cards, err := CollectN(client.AllCards(context.Background(), nil), 4)
+	assert.NoError(t, err)
+	assert.Len(t, cards, 4)
+	assert.Equal(t, 4, cards[3].ID)
+	assert.Equal(t, int32(2), atomic.LoadInt32(requests))
+}
+
+func TestPaginateError(t *testing.T) {
+	calls := 0
+	fetch := func(ctx context.Context, page int) (Page[int], error) {
+		calls++
+		if page == 2 {
+			return nil, errors.New("page failed")
+		}
+		return &ListResponse[int]{Items: []int{1, 2}, PageCount: 3}, nil
+	}
+
+	items, err := Collect(paginate(context.Background(), nil, fetch))
+	assert.EqualError(t, err, "page failed")
+	assert.Equal(t, []int{1, 2}, items)
+	assert.Equal(t, 2, calls)
+}
+
+func TestPaginateContextCancellation(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	calls := 0
+	fetch := func(ctx context.Context, page int) (Page[int], error) {
+		calls++
+		return &ListResponse[int]{Items: []int{page*10 + 1, page*10 + 2}, PageCount: 5}, nil
+	}
+
+	var items []int
+	var iterErr error
+	for item, err := range paginate(ctx, nil, fetch) {
+		if err != nil {
+			iterErr = err
+			break
+		}
+		items = append(items, item)
+		if item == 11 {
+			cancel()
+		}
+	}
+
+	assert.ErrorIs(t, iterErr, context.Canceled)
+	assert.Equal(t, []int{11}, items)
+	assert.Equal(t, 1, calls)
+}
+
+func TestPaginateStopsWithoutPageCount(t *testing.T) {
+	// Without a page count, iteration stops at the total item count or an empty page
+	fetch := func(ctx context.Context, page int) (Page[int], error) {
+		return &ListResponse[int]{Items: []int{page}, TotalItemCount: 3}, nil
+	}
+	items, err := Collect(paginate(context.Background(), nil, fetch))
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1, 2, 3}, items)
+
+	fetch = func(ctx context.Context, page int) (Page[int], error) {
+		if page > 2 {
+			return &ListResponse[int]{}, nil
+		}
+		return &ListResponse[int]{Items: []int{page}}, nil
+	}
+	items, err = Collect(paginate(context.Background(), nil, fetch))
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1, 2}, items)
+}
+
+func TestPaginatePrefetch(t *testing.T) {
+	var inFlight, maxInFlight int32
+	fetch := func(ctx context.Context, page int) (Page[int], error) {
+		n := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+		for {
+			m := atomic.LoadInt32(&maxInFlight)
+			if n <= m || atomic.CompareAndSwapInt32(&maxInFlight, m, n) {
+				break
+			}
+		}
+
+		// Later pages finish first to check that items are yielded in page order
+		time.Sleep(time.Duration(10-page) * 2 * time.Millisecond)
+		return &ListResponse[int]{Items: []int{page*10 + 1, page*10 + 2}, Page: page, PageCount: 8}, nil
+	}
+
+	items, err := Collect(paginate(context.Background(), nil, fetch, WithPrefetch(3)))
+	assert.NoError(t, err)
+	assert.Equal(t, []int{11, 12, 21, 22, 31, 32, 41, 42, 51, 52, 61, 62, 71, 72, 81, 82}, items)
+	assert.LessOrEqual(t, atomic.LoadInt32(&maxInFlight), int32(3))
+	assert.Greater(t, atomic.LoadInt32(&maxInFlight), int32(1))
+}
+
+func TestPaginatePrefetchError(t *testing.T) {
+	var canceled int32
+	fetch := func(ctx context.Context, page int) (Page[int], error) {
+		switch {
+		case page == 3:
+			return nil, errors.New("page failed")
+		case page > 3:
+			// Outstanding fetches are canceled once the error is yielded
+			select {
+			case <-ctx.Done():
+				atomic.AddInt32(&canceled, 1)
+				return nil, ctx.Err()
+			case <-time.After(5 * time.Second):
+				t.Error("Expected outstanding fetch to be canceled")
+			}
+		}
+		return &ListResponse[int]{Items: []int{page}, PageCount: 10}, nil
+	}
+
+	items, err := Collect(paginate(context.Background(), nil, fetch, WithPrefetch(4)))
+	assert.EqualError(t, err, "page failed")
+	assert.Equal(t, []int{1, 2}, items)
+	assert.Eventually(t, func() bool { return atomic.LoadInt32(&canceled) >= 2 }, time.Second, time.Millisecond)
+}
+
+func TestPaginatePrefetchBreak(t *testing.T) {
+	var requests int32
+	fetch := func(ctx context.Context, page int) (Page[int], error) {
+		atomic.AddInt32(&requests, 1)
+		return &ListResponse[int]{Items: []int{page}, PageCount: 100}, nil
+	}
+
+	items, err := CollectN(paginate(context.Background(), nil, fetch, WithPrefetch(2)), 3)
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1, 2, 3}, items)
+
+	// Only pages being fetched or waiting to be yielded are requested
+	time.Sleep(10 * time.Millisecond)
+	assert.LessOrEqual(t, atomic.LoadInt32(&requests), int32(5))
+}
+
+func TestAllCardsWithPrefetch(t *testing.T) {
+	ts, requests := newPagedServer(t, "/api/cards", 25, 5)
+	defer ts.Close()
+
+	client := NewClient("test-api-key", WithBaseURL(ts.URL))
+	ids, err := collectIDs(client.AllCards(context.Background(), nil, WithPrefetch(4)), func(v Card) int { return v.ID })
+	assert.NoError(t, err)
+	assert.Len(t, ids, 25)
+	for i, id := range ids {
+		assert.Equal(t, i+1, id)
+	}
+	assert.Equal(t, int32(5), atomic.LoadInt32(requests))
+}
@@ -3,6 +3,7 @@ package tcgcollector
 import (
 	"context"
 	"fmt"
+	"iter"
 	"net/http"
 	"net/url"
 	"time"
@@ -15,13 +16,7 @@ type ListPokemonStagesParams struct {
 }
 
 // ListPokemonStagesResponse represents the response from listing Pokémon stages
-type ListPokemonStagesResponse struct {
-	Items          []PokemonStage `json:"items"`
-	ItemCount      int            `json:"itemCount"`
-	TotalItemCount int            `json:"totalItemCount"`
-	Page           int            `json:"page"`
-	PageCount      int            `json:"pageCount"`
-}
+type ListPokemonStagesResponse = ListResponse[PokemonStage]
 
 // PokemonStage represents a Pokémon stage
 type PokemonStage struct {
@@ -49,17 +44,30 @@ func (c *Client) ListPokemonStages(ctx context.Context, params *ListPokemonStage
 	}
 
 	var response ListPokemonStagesResponse
-	if err := c.doRequest(ctx, http.MethodGet, path, nil, &response); err != nil {
+	if err := c.doRequest(ctx, "ListPokemonStages", http.MethodGet, path, nil, &response); err != nil {
 		return nil, err
 	}
 
 	return &response, nil
 }
 
+// AllPokemonStages iterates over all Pokémon stages, fetching pages as needed
+func (c *Client) AllPokemonStages(ctx context.Context, params *ListPokemonStagesParams, opts ...PageOption) iter.Seq2[PokemonStage, error] {
+	var p ListPokemonStagesParams
+	if params != nil {
+		p = *params
+	}
+	return paginate(ctx, p.Page, func(ctx context.Context, page int) (Page[PokemonStage], error) {
+		pageParams := p
+		pageParams.Page = &page
+		return c.ListPokemonStages(ctx, &pageParams)
+	}, opts...)
+}
+
 // GetPokemonStage retrieves a single Pokémon stage by ID
 func (c *Client) GetPokemonStage(ctx context.Context, id int) (*PokemonStage, error) {
 	var response PokemonStage
-	if err := c.doRequest(ctx, http.MethodGet, fmt.Sprintf("/api/pokemon-stages/%d", id), nil, &response); err != nil {
+	if err := c.doRequest(ctx, "GetPokemonStage", http.MethodGet, fmt.Sprintf("/api/pokemon-stages/%d", id), nil, &response); err != nil {
 		return nil, err
 	}
 
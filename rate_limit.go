@@ -0,0 +1,177 @@
+package tcgcollector
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// RateLimitGroup is a separate rate limit budget for the requests it matches
+type RateLimitGroup struct {
+	// Name identifies the group
+	Name string
+	// Match reports whether a request belongs to the group
+	Match func(method, path string) bool
+	// RequestsPerSecond is the sustained request rate of the group
+	RequestsPerSecond float64
+	// Burst is the maximum number of requests that can be made at once
+	Burst int
+}
+
+// WithRateLimit limits the rate of requests made by the client. The limit is
+// shared by all goroutines using the client and applies to every request that
+// does not belong to a RateLimitGroup
+func WithRateLimit(requestsPerSecond float64, burst int) ClientOption {
+	return func(c *Client) {
+		c.rateLimiter.defaultBucket = newTokenBucket(requestsPerSecond, burst)
+	}
+}
+
+// WithRateLimitGroup adds a separate rate limit budget for the requests matched by the group.
+// Groups are checked in the order they were added
+func WithRateLimitGroup(group RateLimitGroup) ClientOption {
+	return func(c *Client) {
+		c.rateLimiter.groups = append(c.rateLimiter.groups, rateLimitGroup{
+			match:  group.Match,
+			bucket: newTokenBucket(group.RequestsPerSecond, group.Burst),
+		})
+	}
+}
+
+// MatchPathPrefix returns a RateLimitGroup matcher for requests whose path starts with one of the given prefixes
+func MatchPathPrefix(prefixes ...string) func(method, path string) bool {
+	return func(method, path string) bool {
+		for _, prefix := range prefixes {
+			if strings.HasPrefix(path, prefix) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// MatchMaintenanceEndpoints is a RateLimitGroup matcher for the admin maintenance
+// endpoints that recalculate, regenerate, prune or invalidate server-side data
+func MatchMaintenanceEndpoints(method, path string) bool {
+	if method != http.MethodPost {
+		return false
+	}
+	action := path[strings.LastIndex(path, "/")+1:]
+	for _, prefix := range []string{"recalculate-", "regenerate-", "prune", "invalidate-"} {
+		if strings.HasPrefix(action, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// rateLimiter selects the token bucket that applies to a request
+type rateLimiter struct {
+	defaultBucket *tokenBucket
+	groups        []rateLimitGroup
+}
+
+type rateLimitGroup struct {
+	match  func(method, path string) bool
+	bucket *tokenBucket
+}
+
+// wait blocks until the request is allowed by the applicable rate limit or the context is done
+func (r *rateLimiter) wait(ctx context.Context, method, path string) error {
+	bucket := r.defaultBucket
+	for _, group := range r.groups {
+		if group.match != nil && group.match(method, path) {
+			bucket = group.bucket
+			break
+		}
+	}
+	if bucket == nil {
+		return nil
+	}
+	if err := bucket.wait(ctx); err != nil {
+		return fmt.Errorf("failed to wait for rate limiter: %w", err)
+	}
+	return nil
+}
+
+// tokenBucket is a token bucket rate limiter that is safe for concurrent use
+type tokenBucket struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+// newTokenBucket creates a token bucket, or returns nil if the rate is not positive
+func newTokenBucket(requestsPerSecond float64, burst int) *tokenBucket {
+	if requestsPerSecond <= 0 {
+		return nil
+	}
+	if burst < 1 {
+		burst = 1
+	}
+	return &tokenBucket{
+		rate:   requestsPerSecond,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   time.Now(),
+	}
+}
+
+// wait takes a token from the bucket, blocking until one is available or the context is done
+func (b *tokenBucket) wait(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	delay := b.reserve()
+	if delay <= 0 {
+		return nil
+	}
+
+	if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < delay {
+		b.cancel()
+		return context.DeadlineExceeded
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		b.cancel()
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// reserve takes a token and returns how long the caller must wait before using it.
+// Tokens may go negative so that waiting callers are served in order
+func (b *tokenBucket) reserve() time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := time.Now()
+	b.tokens = math.Min(b.burst, b.tokens+now.Sub(b.last).Seconds()*b.rate)
+	b.last = now
+	b.tokens--
+
+	if b.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-b.tokens / b.rate * float64(time.Second))
+}
+
+// cancel returns a reserved token to the bucket
+func (b *tokenBucket) cancel() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.tokens = math.Min(b.burst, b.tokens+1)
+}
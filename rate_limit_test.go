@@ -0,0 +1,126 @@
+package tcgcollector
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithRateLimit(t *testing.T) {
+	var requests int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer ts.Close()
+
+	client := NewClient("test-api-key", WithBaseURL(ts.URL), WithRateLimit(20, 2))
+
+	start := time.Now()
+	var wg sync.WaitGroup
+	for i := 0; i < 6; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			err := client.doRequest(context.Background(), "Test", http.MethodGet, "/api/cards/1", nil, nil)
+			assert.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+
+	// 2 requests use the burst, the remaining 4 are spaced 50ms apart
+	assert.GreaterOrEqual(t, time.Since(start), 190*time.Millisecond)
+	assert.Equal(t, int32(6), atomic.LoadInt32(&requests))
+}
+
+func TestRateLimitContextCancellation(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer ts.Close()
+
+	client := NewClient("test-api-key", WithBaseURL(ts.URL), WithRateLimit(0.1, 1))
+	err := client.doRequest(context.Background(), "Test", http.MethodGet, "/test", nil, nil)
+	assert.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	err = client.doRequest(ctx, "Test", http.MethodGet, "/test", nil, nil)
+	assert.Error(t, err)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Contains(t, err.Error(), "failed to wait for rate limiter")
+	assert.Less(t, time.Since(start), 50*time.Millisecond)
+}
+
+func TestRateLimitGroup(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer ts.Close()
+
+	client := NewClient("test-api-key",
+		WithBaseURL(ts.URL),
+		WithRateLimit(1000, 100),
+		WithRateLimitGroup(RateLimitGroup{
+			Name:              "maintenance",
+			Match:             MatchMaintenanceEndpoints,
+			RequestsPerSecond: 0.1,
+			Burst:             1,
+		}),
+	)
+
+	// The maintenance budget allows a single request
+	err := client.RecalculateCachedValues(context.Background())
+	assert.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	err = client.RegenerateSlugs(ctx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	// Catalog reads are not affected by the exhausted maintenance budget
+	for i := 0; i < 10; i++ {
+		err := client.doRequest(context.Background(), "Test", http.MethodGet, "/api/cards/1", nil, nil)
+		assert.NoError(t, err)
+	}
+}
+
+func TestMatchPathPrefix(t *testing.T) {
+	match := MatchPathPrefix("/api/users", "/api/audit-log")
+	assert.True(t, match(http.MethodGet, "/api/users/1"))
+	assert.True(t, match(http.MethodGet, "/api/audit-log"))
+	assert.False(t, match(http.MethodGet, "/api/cards"))
+}
+
+func TestMatchMaintenanceEndpoints(t *testing.T) {
+	assert.True(t, MatchMaintenanceEndpoints(http.MethodPost, "/api/cards/recalculate-cached-values"))
+	assert.True(t, MatchMaintenanceEndpoints(http.MethodPost, "/api/expansions/regenerate-slugs"))
+	assert.True(t, MatchMaintenanceEndpoints(http.MethodPost, "/api/users/prune-activity-logs"))
+	assert.True(t, MatchMaintenanceEndpoints(http.MethodPost, "/api/card-database-log/prune"))
+	assert.True(t, MatchMaintenanceEndpoints(http.MethodPost, "/api/card-collection/invalidate-card-list-cache"))
+	assert.False(t, MatchMaintenanceEndpoints(http.MethodPost, "/api/collections"))
+	assert.False(t, MatchMaintenanceEndpoints(http.MethodGet, "/api/cards/regenerate-slugs"))
+}
+
+func TestTokenBucket(t *testing.T) {
+	assert.Nil(t, newTokenBucket(0, 1))
+
+	bucket := newTokenBucket(10, 2)
+	assert.Equal(t, time.Duration(0), bucket.reserve())
+	assert.Equal(t, time.Duration(0), bucket.reserve())
+	delay := bucket.reserve()
+	assert.Greater(t, delay, 90*time.Millisecond)
+	assert.LessOrEqual(t, delay, 100*time.Millisecond)
+
+	bucket.cancel()
+	delay = bucket.reserve()
+	assert.LessOrEqual(t, delay, 100*time.Millisecond)
+}
@@ -3,6 +3,7 @@ package tcgcollector
 import (
 	"context"
 	"fmt"
+	"iter"
 	"net/http"
 	"net/url"
 	"time"
@@ -15,13 +16,7 @@ type ListRegulationMarksParams struct {
 }
 
 // ListRegulationMarksResponse represents the response from listing regulation marks
-type ListRegulationMarksResponse struct {
-	Items          []RegulationMark `json:"items"`
-	ItemCount      int              `json:"itemCount"`
-	TotalItemCount int              `json:"totalItemCount"`
-	Page           int              `json:"page"`
-	PageCount      int              `json:"pageCount"`
-}
+type ListRegulationMarksResponse = ListResponse[RegulationMark]
 
 // RegulationMark represents a regulation mark
 type RegulationMark struct {
@@ -49,17 +44,30 @@ func (c *Client) ListRegulationMarks(ctx context.Context, params *ListRegulation
 	}
 
 	var response ListRegulationMarksResponse
-	if err := c.doRequest(ctx, http.MethodGet, path, nil, &response); err != nil {
+	if err := c.doRequest(ctx, "ListRegulationMarks", http.MethodGet, path, nil, &response); err != nil {
 		return nil, err
 	}
 
 	return &response, nil
 }
 
+// AllRegulationMarks iterates over all regulation marks, fetching pages as needed
+func (c *Client) AllRegulationMarks(ctx context.Context, params *ListRegulationMarksParams, opts ...PageOption) iter.Seq2[RegulationMark, error] {
+	var p ListRegulationMarksParams
+	if params != nil {
+		p = *params
+	}
+	return paginate(ctx, p.Page, func(ctx context.Context, page int) (Page[RegulationMark], error) {
+		pageParams := p
+		pageParams.Page = &page
+		return c.ListRegulationMarks(ctx, &pageParams)
+	}, opts...)
+}
+
 // GetRegulationMark retrieves a single regulation mark by ID
 func (c *Client) GetRegulationMark(ctx context.Context, id int) (*RegulationMark, error) {
 	var response RegulationMark
-	if err := c.doRequest(ctx, http.MethodGet, fmt.Sprintf("/api/regulation-marks/%d", id), nil, &response); err != nil {
+	if err := c.doRequest(ctx, "GetRegulationMark", http.MethodGet, fmt.Sprintf("/api/regulation-marks/%d", id), nil, &response); err != nil {
 		return nil, err
 	}
 
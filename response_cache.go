@@ -0,0 +1,293 @@
+package tcgcollector
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"maps"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ResponseCacheConfig configures the response cache enabled by WithResponseCache
+type ResponseCacheConfig struct {
+	// TTL is how long responses of operations without an entry in
+	// OperationTTLs are served from the cache. Zero disables caching for them
+	TTL time.Duration
+
+	// OperationTTLs overrides TTL by operation name, e.g. "ListCardConditions".
+	// A zero TTL disables caching for the operation
+	OperationTTLs map[string]time.Duration
+
+	// StaleWhileRevalidate is how long after expiry a response is still served
+	// while it is refreshed in the background
+	StaleWhileRevalidate time.Duration
+
+	// MaxEntries bounds the number of cached responses when Store is nil
+	MaxEntries int
+
+	// MaxEntrySize is the largest response body in bytes that is cached. Zero means no limit
+	MaxEntrySize int
+
+	// Store holds the cached responses. It defaults to a MemoryCache with MaxEntries entries
+	Store CacheStore
+
+	// Invalidate returns additional path prefixes whose cached responses are
+	// discarded after the mutating operation op succeeds. The collection that
+	// op belongs to, e.g. "/api/collections" for DeleteCollection, is always invalidated
+	Invalidate func(op *Operation) []string
+}
+
+// WithResponseCache serves GET responses from a cache for the configured TTLs.
+// Mutating operations invalidate the cached responses of the resources they affect
+func WithResponseCache(config ResponseCacheConfig) ClientOption {
+	return func(c *Client) {
+		if err := config.validate(); err != nil {
+			c.configError(err)
+			return
+		}
+		c.responseCache = newResponseCache(config)
+	}
+}
+
+// validate checks the configuration of the response cache
+func (config *ResponseCacheConfig) validate() error {
+	if config.TTL < 0 || config.StaleWhileRevalidate < 0 {
+		return errors.New("invalid response cache: durations cannot be negative")
+	}
+	for name, ttl := range config.OperationTTLs {
+		if ttl < 0 {
+			return fmt.Errorf("invalid response cache: TTL for %s cannot be negative", name)
+		}
+	}
+	if config.MaxEntries < 0 || config.MaxEntrySize < 0 {
+		return errors.New("invalid response cache: size limits cannot be negative")
+	}
+	return nil
+}
+
+// responseCache is a TTL cache of GET responses
+type responseCache struct {
+	config ResponseCacheConfig
+	store  CacheStore
+	// maxAge is how long after it was stored an entry can still be served
+	maxAge time.Duration
+
+	mu sync.Mutex
+	// invalidated maps invalidated path prefixes to the time they were
+	// invalidated. Entries stored before then are not served
+	invalidated map[string]time.Time
+	// refreshing holds the keys that are being refreshed in the background
+	refreshing map[string]bool
+}
+
+func newResponseCache(config ResponseCacheConfig) *responseCache {
+	store := config.Store
+	if store == nil {
+		store = NewMemoryCache(config.MaxEntries)
+	}
+	maxTTL := config.TTL
+	for _, ttl := range config.OperationTTLs {
+		maxTTL = max(maxTTL, ttl)
+	}
+	return &responseCache{
+		config:      config,
+		store:       store,
+		maxAge:      maxTTL + config.StaleWhileRevalidate,
+		invalidated: make(map[string]time.Time),
+		refreshing:  make(map[string]bool),
+	}
+}
+
+// ttl returns how long responses of op are cached
+func (rc *responseCache) ttl(op *Operation) time.Duration {
+	if ttl, ok := rc.config.OperationTTLs[op.Name]; ok {
+		return ttl
+	}
+	return rc.config.TTL
+}
+
+// handler returns a Handler that serves cached GET responses and invalidates
+// them after mutating operations
+func (rc *responseCache) handler(next Handler) Handler {
+	return func(ctx context.Context, op *Operation) (*Response, error) {
+		switch op.Method {
+		case http.MethodGet:
+		case http.MethodHead, http.MethodOptions:
+			return next(ctx, op)
+		default:
+			resp, err := next(ctx, op)
+			if err == nil {
+				rc.invalidateAfter(op)
+			}
+			return resp, err
+		}
+
+		ttl := rc.ttl(op)
+		if ttl <= 0 || callOptionsFromContext(ctx).disableCache {
+			return next(ctx, op)
+		}
+
+		key := requestKey(ctx, op)
+		if entry, ok := rc.get(key); ok {
+			age := time.Since(entry.StoredAt)
+			if age < ttl {
+				return entry.response(), nil
+			}
+			if age < ttl+rc.config.StaleWhileRevalidate {
+				rc.refresh(ctx, key, op, next)
+				return entry.response(), nil
+			}
+		}
+
+		return rc.fetch(ctx, key, op, next)
+	}
+}
+
+// get returns the entry stored under key unless it was invalidated
+func (rc *responseCache) get(key string) (*CacheEntry, bool) {
+	entry, ok := rc.store.Get(key)
+	if !ok {
+		return nil, false
+	}
+	if rc.isInvalidated(entry) {
+		rc.store.Delete(key)
+		return nil, false
+	}
+	return entry, true
+}
+
+// fetch performs op and caches a successful response under key
+func (rc *responseCache) fetch(ctx context.Context, key string, op *Operation, next Handler) (*Response, error) {
+	// The response is dated when the request starts, so that an invalidation
+	// while it is in flight also discards it
+	start := time.Now()
+	resp, err := next(ctx, op)
+	if err != nil {
+		return resp, err
+	}
+	if resp.StatusCode == http.StatusOK && (rc.config.MaxEntrySize == 0 || len(resp.Body) <= rc.config.MaxEntrySize) {
+		rc.store.Set(key, &CacheEntry{
+			Path:       op.Path,
+			StatusCode: resp.StatusCode,
+			Header:     resp.Header.Clone(),
+			Body:       resp.Body,
+			StoredAt:   start,
+		})
+	}
+	return resp, nil
+}
+
+// refresh fetches op again in the background unless a refresh of key is already running
+func (rc *responseCache) refresh(ctx context.Context, key string, op *Operation, next Handler) {
+	rc.mu.Lock()
+	if rc.refreshing[key] {
+		rc.mu.Unlock()
+		return
+	}
+	rc.refreshing[key] = true
+	rc.mu.Unlock()
+
+	// The refresh outlives the caller, but keeps its context values such as the token
+	ctx = context.WithoutCancel(ctx)
+	refreshOp := *op
+	refreshOp.Header = op.Header.Clone()
+	go func() {
+		defer func() {
+			rc.mu.Lock()
+			delete(rc.refreshing, key)
+			rc.mu.Unlock()
+		}()
+		rc.fetch(ctx, key, &refreshOp, next)
+	}()
+}
+
+// invalidateAfter discards the cached responses affected by the mutating operation op
+func (rc *responseCache) invalidateAfter(op *Operation) {
+	prefixes := []string{resourcePath(op.Path)}
+	if rc.config.Invalidate != nil {
+		prefixes = append(prefixes, rc.config.Invalidate(op)...)
+	}
+	rc.invalidate(prefixes...)
+}
+
+// invalidate discards the cached responses of paths under any of the
+// prefixes. The store cannot be searched by path, so the prefixes are
+// remembered and entries stored before the invalidation are discarded when
+// they are read. Prefixes are forgotten once every entry they cover has expired
+func (rc *responseCache) invalidate(prefixes ...string) {
+	now := time.Now()
+
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	for prefix, at := range rc.invalidated {
+		if now.Sub(at) > rc.maxAge {
+			delete(rc.invalidated, prefix)
+		}
+	}
+	for _, prefix := range prefixes {
+		rc.invalidated[prefix] = now
+	}
+}
+
+// isInvalidated reports whether entry was stored before a prefix of its path was invalidated
+func (rc *responseCache) isInvalidated(entry *CacheEntry) bool {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	for prefix, at := range rc.invalidated {
+		if !entry.StoredAt.After(at) && hasPathPrefix(entry.Path, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// InvalidateCache discards the responses cached by WithResponseCache for paths
+// under any of the prefixes, e.g. "/api/cards/12" or "/api/sets". Entries
+// persisted by a CacheStore are covered as well, but invalidations made by
+// other clients or processes sharing the store are not seen
+func (c *Client) InvalidateCache(pathPrefixes ...string) {
+	if c.responseCache != nil {
+		c.responseCache.invalidate(pathPrefixes...)
+	}
+}
+
+// requestKey identifies the response to a GET request for op. Requests made
+// with a per-call token or different headers get different keys
+func requestKey(ctx context.Context, op *Operation) string {
+	token := callOptionsFromContext(ctx).token
+	if token == "" && len(op.Header) == 0 {
+		return op.Path
+	}
+
+	h := sha256.New()
+	h.Write([]byte(token))
+	names := slices.Sorted(maps.Keys(op.Header))
+	for _, name := range names {
+		fmt.Fprintf(h, "\n%s: %q", name, op.Header[name])
+	}
+	return hex.EncodeToString(h.Sum(nil)[:8]) + " " + op.Path
+}
+
+// resourcePath returns the collection path of the resource that path belongs
+// to, e.g. "/api/collections" for "/api/collections/3/cards"
+func resourcePath(path string) string {
+	path, _, _ = strings.Cut(path, "?")
+	segments := strings.SplitN(strings.TrimPrefix(path, "/"), "/", 3)
+	if len(segments) >= 2 && segments[0] == "api" {
+		return "/api/" + segments[1]
+	}
+	return path
+}
+
+// hasPathPrefix reports whether path, which may include a query string, is
+// prefix or lies below it
+func hasPathPrefix(path, prefix string) bool {
+	rest, ok := strings.CutPrefix(path, strings.TrimSuffix(prefix, "/"))
+	return ok && (rest == "" || rest[0] == '/' || rest[0] == '?')
+}
@@ -0,0 +1,254 @@
+package tcgcollector
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// newCountingServer returns a test server that counts requests by path and
+// responds with the version of the data, which starts at 1
+func newCountingServer(t *testing.T) (*httptest.Server, map[string]*int32, *int32) {
+	var version int32 = 1
+	counts := map[string]*int32{}
+	for _, path := range []string{"/api/card-conditions", "/api/cards/1/prices", "/api/collections/3", "/api/card-variants/5", "/api/cards/1"} {
+		counts[path] = new(int32)
+	}
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			w.Write([]byte(`{"id": 1}`))
+			return
+		}
+		count, ok := counts[r.URL.Path]
+		if !ok {
+			t.Errorf("Unexpected path %s", r.URL.Path)
+			return
+		}
+		atomic.AddInt32(count, 1)
+
+		v := atomic.LoadInt32(&version)
+		switch r.URL.Path {
+		case "/api/card-conditions":
+			fmt.Fprintf(w, `[{"id": %d, "name": "Near Mint"}]`, v)
+		case "/api/cards/1/prices":
+			fmt.Fprintf(w, `{"items": [{"id": %d}]}`, v)
+		default:
+			fmt.Fprintf(w, `{"id": %d}`, v)
+		}
+	}))
+	return ts, counts, &version
+}
+
+func TestResponseCacheOperationTTLs(t *testing.T) {
+	ts, counts, _ := newCountingServer(t)
+	defer ts.Close()
+
+	client := NewClient("test-api-key", WithBaseURL(ts.URL), WithResponseCache(ResponseCacheConfig{
+		OperationTTLs: map[string]time.Duration{"ListCardConditions": 24 * time.Hour},
+	}))
+
+	for i := 0; i < 3; i++ {
+		conditions, err := client.ListCardConditions(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, "Near Mint", conditions[0].Name)
+
+		_, err = client.GetCardPrices(context.Background(), 1)
+		assert.NoError(t, err)
+	}
+
+	// Operations without a TTL are not cached
+	assert.Equal(t, int32(1), atomic.LoadInt32(counts["/api/card-conditions"]))
+	assert.Equal(t, int32(3), atomic.LoadInt32(counts["/api/cards/1/prices"]))
+}
+
+func TestResponseCacheStaleWhileRevalidate(t *testing.T) {
+	ts, counts, version := newCountingServer(t)
+	defer ts.Close()
+
+	client := NewClient("test-api-key", WithBaseURL(ts.URL), WithResponseCache(ResponseCacheConfig{
+		TTL:                  20 * time.Millisecond,
+		StaleWhileRevalidate: time.Hour,
+	}))
+
+	card, err := client.GetCard(context.Background(), 1)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, card.ID)
+
+	atomic.StoreInt32(version, 2)
+	time.Sleep(30 * time.Millisecond)
+
+	// The stale response is served while it is refreshed in the background
+	card, err = client.GetCard(context.Background(), 1)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, card.ID)
+
+	assert.Eventually(t, func() bool {
+		card, err := client.GetCard(context.Background(), 1)
+		return err == nil && card.ID == 2
+	}, time.Second, time.Millisecond)
+	assert.Equal(t, int32(2), atomic.LoadInt32(counts["/api/cards/1"]))
+}
+
+func TestResponseCacheExpiry(t *testing.T) {
+	ts, counts, version := newCountingServer(t)
+	defer ts.Close()
+
+	client := NewClient("test-api-key", WithBaseURL(ts.URL), WithResponseCache(ResponseCacheConfig{TTL: 10 * time.Millisecond}))
+	_, err := client.GetCard(context.Background(), 1)
+	assert.NoError(t, err)
+
+	atomic.StoreInt32(version, 2)
+	time.Sleep(20 * time.Millisecond)
+
+	card, err := client.GetCard(context.Background(), 1)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, card.ID)
+	assert.Equal(t, int32(2), atomic.LoadInt32(counts["/api/cards/1"]))
+}
+
+func TestResponseCacheInvalidation(t *testing.T) {
+	ts, counts, _ := newCountingServer(t)
+	defer ts.Close()
+
+	client := NewClient("test-api-key", WithBaseURL(ts.URL), WithResponseCache(ResponseCacheConfig{
+		TTL: time.Hour,
+		Invalidate: func(op *Operation) []string {
+			if op.Name == "UpdateCardVariant" {
+				return []string{"/api/cards"}
+			}
+			return nil
+		},
+	}))
+	ctx := context.Background()
+
+	get := func() {
+		_, err := client.GetCollection(ctx, 3)
+		assert.NoError(t, err)
+		_, err = client.GetCardVariant(ctx, 5)
+		assert.NoError(t, err)
+		_, err = client.GetCard(ctx, 1)
+		assert.NoError(t, err)
+		_, err = client.ListCardConditions(ctx)
+		assert.NoError(t, err)
+	}
+	get()
+	get()
+
+	// Deleting a collection only invalidates collections
+	assert.NoError(t, client.DeleteCollection(ctx, 3))
+	get()
+	assert.Equal(t, int32(2), atomic.LoadInt32(counts["/api/collections/3"]))
+	assert.Equal(t, int32(1), atomic.LoadInt32(counts["/api/card-variants/5"]))
+
+	// The hook adds cards to the resources invalidated by updating a variant
+	_, err := client.UpdateCardVariant(ctx, 5, &CardVariant{})
+	assert.NoError(t, err)
+	get()
+	assert.Equal(t, int32(2), atomic.LoadInt32(counts["/api/card-variants/5"]))
+	assert.Equal(t, int32(2), atomic.LoadInt32(counts["/api/cards/1"]))
+
+	client.InvalidateCache("/api/card-conditions")
+	get()
+	assert.Equal(t, int32(2), atomic.LoadInt32(counts["/api/card-conditions"]))
+	assert.Equal(t, int32(2), atomic.LoadInt32(counts["/api/collections/3"]))
+}
+
+func TestResponseCacheSizeBounds(t *testing.T) {
+	ts, counts, _ := newCountingServer(t)
+	defer ts.Close()
+
+	client := NewClient("test-api-key", WithBaseURL(ts.URL), WithResponseCache(ResponseCacheConfig{
+		TTL:          time.Hour,
+		MaxEntries:   1,
+		MaxEntrySize: 16,
+	}))
+	ctx := context.Background()
+
+	for i := 0; i < 2; i++ {
+		_, err := client.GetCard(ctx, 1)
+		assert.NoError(t, err)
+		_, err = client.ListCardConditions(ctx)
+		assert.NoError(t, err)
+	}
+
+	// The card conditions are too large to cache
+	assert.Equal(t, int32(1), atomic.LoadInt32(counts["/api/cards/1"]))
+	assert.Equal(t, int32(2), atomic.LoadInt32(counts["/api/card-conditions"]))
+
+	_, err := client.GetCollection(ctx, 3)
+	assert.NoError(t, err)
+	_, err = client.GetCard(ctx, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(2), atomic.LoadInt32(counts["/api/cards/1"]))
+}
+
+func TestResponseCacheSeparatesPerCallTokens(t *testing.T) {
+	ts, counts, _ := newCountingServer(t)
+	defer ts.Close()
+
+	client := NewClient("test-api-key", WithBaseURL(ts.URL), WithResponseCache(ResponseCacheConfig{TTL: time.Hour}))
+	for _, ctx := range []context.Context{context.Background(), WithCallOptions(context.Background(), BearerToken("other")), context.Background()} {
+		_, err := client.GetCard(ctx, 1)
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, int32(2), atomic.LoadInt32(counts["/api/cards/1"]))
+}
+
+func TestResponseCacheInvalidatesPersistedEntries(t *testing.T) {
+	ts, counts, _ := newCountingServer(t)
+	defer ts.Close()
+
+	store, err := NewDiskCache(t.TempDir())
+	assert.NoError(t, err)
+	config := ResponseCacheConfig{TTL: time.Hour, Store: store}
+	ctx := context.Background()
+
+	client := NewClient("test-api-key", WithBaseURL(ts.URL), WithResponseCache(config))
+	_, err = client.GetCard(ctx, 1)
+	assert.NoError(t, err)
+	_, err = client.GetCollection(ctx, 3)
+	assert.NoError(t, err)
+
+	// A new client serves the persisted entries and can still invalidate them
+	client = NewClient("test-api-key", WithBaseURL(ts.URL), WithResponseCache(config))
+	_, err = client.GetCard(ctx, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(1), atomic.LoadInt32(counts["/api/cards/1"]))
+
+	client.InvalidateCache("/api/cards")
+	assert.NoError(t, client.DeleteCollection(ctx, 3))
+	for i := 0; i < 2; i++ {
+		_, err = client.GetCard(ctx, 1)
+		assert.NoError(t, err)
+		_, err = client.GetCollection(ctx, 3)
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, int32(2), atomic.LoadInt32(counts["/api/cards/1"]))
+	assert.Equal(t, int32(2), atomic.LoadInt32(counts["/api/collections/3"]))
+}
+
+func TestResponseCacheForgetsExpiredInvalidations(t *testing.T) {
+	rc := newResponseCache(ResponseCacheConfig{TTL: 10 * time.Millisecond})
+	for i := 0; i < 100; i++ {
+		rc.invalidate(fmt.Sprintf("/api/cards/%d", i))
+	}
+	assert.Len(t, rc.invalidated, 100)
+
+	time.Sleep(20 * time.Millisecond)
+	rc.invalidate("/api/sets")
+	assert.Len(t, rc.invalidated, 1)
+}
+
+func TestResponseCacheInvalidConfig(t *testing.T) {
+	_, err := NewClientWithOptions("test-api-key", WithResponseCache(ResponseCacheConfig{
+		OperationTTLs: map[string]time.Duration{"GetCard": -time.Second},
+	}))
+	assert.EqualError(t, err, "invalid client configuration: invalid response cache: TTL for GetCard cannot be negative")
+}
@@ -0,0 +1,168 @@
+package tcgcollector
+
+import (
+	"context"
+	"errors"
+	"math"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	defaultMaxRetries = 3
+	defaultBaseDelay  = 500 * time.Millisecond
+	defaultMaxDelay   = 30 * time.Second
+)
+
+// RetryPolicy configures how failed requests are retried
+type RetryPolicy struct {
+	// MaxRetries is the maximum number of retries after the first attempt
+	MaxRetries int
+	// BaseDelay is the delay before the first retry, doubled on each subsequent retry
+	BaseDelay time.Duration
+	// MaxDelay caps the computed backoff delay. Zero means no cap
+	MaxDelay time.Duration
+	// MaxRetryAfter is the longest Retry-After wait that is honored. A longer
+	// wait ends the retries instead of stalling the caller. It defaults to
+	// MaxDelay or 30 seconds, whichever is longer
+	MaxRetryAfter time.Duration
+	// ShouldRetry reports whether a failed attempt is retryable. resp is nil
+	// when the request failed with a network error. Defaults to DefaultShouldRetry
+	ShouldRetry func(resp *http.Response, err error) bool
+}
+
+// DefaultRetryPolicy returns a retry policy with sensible defaults
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxRetries:  defaultMaxRetries,
+		BaseDelay:   defaultBaseDelay,
+		MaxDelay:    defaultMaxDelay,
+		ShouldRetry: DefaultShouldRetry,
+	}
+}
+
+// DefaultShouldRetry retries network errors and 429, 502, 503 and 504 responses
+func DefaultShouldRetry(resp *http.Response, err error) bool {
+	if resp == nil {
+		return err != nil && !errors.Is(err, context.Canceled) && !errors.Is(err, context.DeadlineExceeded)
+	}
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// WithRetryPolicy enables automatic retries for idempotent requests, including
+// POST requests that carry an Idempotency-Key
+func WithRetryPolicy(policy *RetryPolicy) ClientOption {
+	return func(c *Client) {
+		c.retryPolicy = policy
+	}
+}
+
+// isIdempotent reports whether a request with the given method and headers can be
+// safely retried. POST requests are only retried with an Idempotency-Key
+func isIdempotent(method string, header http.Header) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	case http.MethodPost:
+		return hasIdempotencyKey(header)
+	}
+	return false
+}
+
+// retryDelay reports whether the given failed attempt should be retried and how long to wait first
+func (p *RetryPolicy) retryDelay(method string, header http.Header, attempt int, resp *http.Response, err error) (time.Duration, bool) {
+	if p == nil || attempt >= p.MaxRetries || !isIdempotent(method, header) {
+		return 0, false
+	}
+
+	shouldRetry := p.ShouldRetry
+	if shouldRetry == nil {
+		shouldRetry = DefaultShouldRetry
+	}
+	if !shouldRetry(resp, err) {
+		return 0, false
+	}
+
+	if resp != nil {
+		if delay, ok := parseRetryAfter(resp.Header.Get("Retry-After")); ok {
+			return delay, delay <= p.maxRetryAfter()
+		}
+	}
+	return p.backoff(attempt), true
+}
+
+// maxRetryAfter returns the longest Retry-After wait that is honored
+func (p *RetryPolicy) maxRetryAfter() time.Duration {
+	if p.MaxRetryAfter > 0 {
+		return p.MaxRetryAfter
+	}
+	return max(p.MaxDelay, defaultMaxDelay)
+}
+
+// backoff returns the exponential backoff delay for the given attempt with jitter applied
+func (p *RetryPolicy) backoff(attempt int) time.Duration {
+	delay := p.BaseDelay
+	for i := 0; i < attempt && (p.MaxDelay <= 0 || delay < p.MaxDelay); i++ {
+		// Stop doubling before the delay overflows
+		if delay > math.MaxInt64/2 {
+			delay = math.MaxInt64
+			break
+		}
+		delay *= 2
+	}
+	if p.MaxDelay > 0 && delay > p.MaxDelay {
+		delay = p.MaxDelay
+	}
+	if delay <= 0 {
+		return 0
+	}
+
+	// Wait at least half of the delay and randomize the rest
+	half := delay / 2
+	return half + rand.N(delay-half+1)
+}
+
+// parseRetryAfter parses a Retry-After header given in seconds or as an HTTP date
+func parseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+	if t, err := http.ParseTime(value); err == nil {
+		delay := time.Until(t)
+		if delay < 0 {
+			delay = 0
+		}
+		return delay, true
+	}
+	return 0, false
+}
+
+// sleep waits for the given delay or until the context is done. It returns
+// false without waiting when the context deadline would expire first
+func sleep(ctx context.Context, delay time.Duration) bool {
+	if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < delay {
+		return false
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
@@ -0,0 +1,265 @@
+package tcgcollector
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxRetries: 3,
+		BaseDelay:  time.Millisecond,
+		MaxDelay:   5 * time.Millisecond,
+	}
+}
+
+func TestRetryOnServiceUnavailable(t *testing.T) {
+	var attempts int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&attempts, 1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id": 1, "name": "Test Card"}`))
+	}))
+	defer ts.Close()
+
+	client := NewClient("test-api-key", WithBaseURL(ts.URL), WithRetryPolicy(testRetryPolicy()))
+	card, err := client.GetCard(context.Background(), 1)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, card.ID)
+	assert.Equal(t, int32(3), atomic.LoadInt32(&attempts))
+}
+
+func TestRetryGivesUpAfterMaxRetries(t *testing.T) {
+	var attempts int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&attempts, 1)
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer ts.Close()
+
+	client := NewClient("test-api-key", WithBaseURL(ts.URL), WithRetryPolicy(testRetryPolicy()))
+	_, err := client.GetCard(context.Background(), 1)
+	assert.Error(t, err)
+	assert.True(t, IsServerError(err))
+	assert.Equal(t, int32(4), atomic.LoadInt32(&attempts))
+}
+
+func TestRetryDoesNotRetryPost(t *testing.T) {
+	var attempts int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&attempts, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer ts.Close()
+
+	// POST requests without an Idempotency-Key are not retried
+	client := NewClient("test-api-key", WithBaseURL(ts.URL), WithRetryPolicy(testRetryPolicy()))
+	err := client.doRequest(context.Background(), "Test", http.MethodPost, "/api/collections", &Collection{Name: "Test"}, nil)
+	assert.Error(t, err)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&attempts))
+}
+
+func TestRetryDoesNotRetryClientErrors(t *testing.T) {
+	var attempts int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&attempts, 1)
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer ts.Close()
+
+	client := NewClient("test-api-key", WithBaseURL(ts.URL), WithRetryPolicy(testRetryPolicy()))
+	_, err := client.GetCard(context.Background(), 1)
+	assert.True(t, IsNotFound(err))
+	assert.Equal(t, int32(1), atomic.LoadInt32(&attempts))
+}
+
+func TestRetryRecreatesRequestBody(t *testing.T) {
+	var attempts int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body Collection
+		err := json.NewDecoder(r.Body).Decode(&body)
+		assert.NoError(t, err)
+		assert.Equal(t, "Test", body.Name)
+
+		if atomic.AddInt32(&attempts, 1) < 2 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(body)
+	}))
+	defer ts.Close()
+
+	client := NewClient("test-api-key", WithBaseURL(ts.URL), WithRetryPolicy(testRetryPolicy()))
+	result, err := client.UpdateCollection(context.Background(), 1, &Collection{Name: "Test"})
+	assert.NoError(t, err)
+	assert.Equal(t, "Test", result.Name)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&attempts))
+}
+
+func TestRetryNetworkError(t *testing.T) {
+	transport := &countingRoundTripper{}
+	client := NewClient("test-api-key",
+		WithHTTPClient(&http.Client{Transport: transport}),
+		WithRetryPolicy(testRetryPolicy()),
+	)
+	err := client.doRequest(context.Background(), "Test", http.MethodGet, "/test", nil, nil)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to send request")
+	assert.Equal(t, int32(4), atomic.LoadInt32(&transport.calls))
+}
+
+func TestRetryCustomShouldRetry(t *testing.T) {
+	var attempts int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&attempts, 1) < 2 {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer ts.Close()
+
+	policy := testRetryPolicy()
+	policy.ShouldRetry = func(resp *http.Response, err error) bool {
+		return resp != nil && resp.StatusCode == http.StatusInternalServerError
+	}
+	client := NewClient("test-api-key", WithBaseURL(ts.URL), WithRetryPolicy(policy))
+	err := client.doRequest(context.Background(), "Test", http.MethodGet, "/test", nil, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&attempts))
+}
+
+func TestRetryHonorsRetryAfter(t *testing.T) {
+	var attempts int32
+	var first time.Time
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&attempts, 1) == 1 {
+			first = time.Now()
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		assert.GreaterOrEqual(t, time.Since(first), time.Second)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer ts.Close()
+
+	client := NewClient("test-api-key", WithBaseURL(ts.URL), WithRetryPolicy(testRetryPolicy()))
+	err := client.doRequest(context.Background(), "Test", http.MethodGet, "/test", nil, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&attempts))
+}
+
+func TestRetryRespectsContextDeadline(t *testing.T) {
+	var attempts int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&attempts, 1)
+		w.Header().Set("Retry-After", "60")
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer ts.Close()
+
+	client := NewClient("test-api-key", WithBaseURL(ts.URL), WithRetryPolicy(testRetryPolicy()))
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	start := time.Now()
+	err := client.doRequest(ctx, "Test", http.MethodGet, "/test", nil, nil)
+	assert.Error(t, err)
+	assert.True(t, IsServerError(err))
+	assert.Less(t, time.Since(start), time.Second)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&attempts))
+}
+
+func TestRetryBackoff(t *testing.T) {
+	policy := &RetryPolicy{BaseDelay: 100 * time.Millisecond, MaxDelay: time.Second}
+	for attempt, want := range []time.Duration{100 * time.Millisecond, 200 * time.Millisecond, 400 * time.Millisecond, 800 * time.Millisecond, time.Second, time.Second} {
+		delay := policy.backoff(attempt)
+		assert.GreaterOrEqual(t, delay, want/2)
+		assert.LessOrEqual(t, delay, want)
+	}
+}
+
+func TestRetryBackoffWithoutMaxDelay(t *testing.T) {
+	policy := &RetryPolicy{BaseDelay: 100 * time.Millisecond}
+	for attempt, want := range []time.Duration{100 * time.Millisecond, 200 * time.Millisecond, 400 * time.Millisecond, 800 * time.Millisecond, 1600 * time.Millisecond} {
+		delay := policy.backoff(attempt)
+		assert.GreaterOrEqual(t, delay, want/2)
+		assert.LessOrEqual(t, delay, want)
+	}
+
+	// Large attempts saturate instead of overflowing
+	assert.Greater(t, policy.backoff(100), time.Duration(0))
+}
+
+func TestRetryAfterLimit(t *testing.T) {
+	policy := &RetryPolicy{MaxRetries: 3, BaseDelay: time.Millisecond, MaxDelay: time.Minute}
+	resp := &http.Response{StatusCode: http.StatusServiceUnavailable, Header: http.Header{"Retry-After": {"60"}}}
+	delay, retry := policy.retryDelay(http.MethodGet, nil, 0, resp, nil)
+	assert.True(t, retry)
+	assert.Equal(t, time.Minute, delay)
+
+	resp.Header.Set("Retry-After", "86400")
+	_, retry = policy.retryDelay(http.MethodGet, nil, 0, resp, nil)
+	assert.False(t, retry)
+
+	// Without MaxDelay, waits up to 30 seconds are honored
+	policy.MaxDelay = 0
+	resp.Header.Set("Retry-After", "30")
+	_, retry = policy.retryDelay(http.MethodGet, nil, 0, resp, nil)
+	assert.True(t, retry)
+	resp.Header.Set("Retry-After", "31")
+	_, retry = policy.retryDelay(http.MethodGet, nil, 0, resp, nil)
+	assert.False(t, retry)
+
+	policy.MaxRetryAfter = time.Hour
+	_, retry = policy.retryDelay(http.MethodGet, nil, 0, resp, nil)
+	assert.True(t, retry)
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	delay, ok := parseRetryAfter("5")
+	assert.True(t, ok)
+	assert.Equal(t, 5*time.Second, delay)
+
+	delay, ok = parseRetryAfter(time.Now().Add(time.Hour).UTC().Format(http.TimeFormat))
+	assert.True(t, ok)
+	assert.Greater(t, delay, 59*time.Minute)
+
+	_, ok = parseRetryAfter("")
+	assert.False(t, ok)
+	_, ok = parseRetryAfter("-1")
+	assert.False(t, ok)
+	_, ok = parseRetryAfter("soon")
+	assert.False(t, ok)
+}
+
+func TestDefaultShouldRetry(t *testing.T) {
+	assert.True(t, DefaultShouldRetry(&http.Response{StatusCode: http.StatusTooManyRequests}, nil))
+	assert.True(t, DefaultShouldRetry(&http.Response{StatusCode: http.StatusGatewayTimeout}, nil))
+	assert.False(t, DefaultShouldRetry(&http.Response{StatusCode: http.StatusInternalServerError}, nil))
+	assert.False(t, DefaultShouldRetry(&http.Response{StatusCode: http.StatusBadRequest}, nil))
+	assert.True(t, DefaultShouldRetry(nil, assert.AnError))
+	assert.False(t, DefaultShouldRetry(nil, context.Canceled))
+}
+
+// countingRoundTripper is a mock http.RoundTripper that counts calls and always returns an error
+type countingRoundTripper struct {
+	calls int32
+}
+
+func (c *countingRoundTripper) RoundTrip(*http.Request) (*http.Response, error) {
+	atomic.AddInt32(&c.calls, 1)
+	return nil, assert.AnError
+}
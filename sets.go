@@ -3,6 +3,7 @@ package tcgcollector
 import (
 	"context"
 	"fmt"
+	"iter"
 	"net/http"
 	"net/url"
 	"time"
@@ -44,16 +45,29 @@ func (c *Client) ListSets(ctx context.Context, params *ListSetsParams) (*ListRes
 	}
 
 	var result ListResponse[Set]
-	if err := c.doRequest(ctx, http.MethodGet, path, nil, &result); err != nil {
+	if err := c.doRequest(ctx, "ListSets", http.MethodGet, path, nil, &result); err != nil {
 		return nil, err
 	}
 	return &result, nil
 }
 
+// AllSets iterates over all sets matching params, fetching pages as needed
+func (c *Client) AllSets(ctx context.Context, params *ListSetsParams, opts ...PageOption) iter.Seq2[Set, error] {
+	var p ListSetsParams
+	if params != nil {
+		p = *params
+	}
+	return paginate(ctx, p.Page, func(ctx context.Context, page int) (Page[Set], error) {
+		pageParams := p
+		pageParams.Page = &page
+		return c.ListSets(ctx, &pageParams)
+	}, opts...)
+}
+
 // GetSet gets a single set by ID
 func (c *Client) GetSet(ctx context.Context, id int) (*Set, error) {
 	var result Set
-	if err := c.doRequest(ctx, http.MethodGet, fmt.Sprintf("/api/sets/%d", id), nil, &result); err != nil {
+	if err := c.doRequest(ctx, "GetSet", http.MethodGet, fmt.Sprintf("/api/sets/%d", id), nil, &result); err != nil {
 		return nil, err
 	}
 	return &result, nil
@@ -62,8 +76,15 @@ func (c *Client) GetSet(ctx context.Context, id int) (*Set, error) {
 // GetSetCards gets all cards in a set
 func (c *Client) GetSetCards(ctx context.Context, setID int) (*ListResponse[Card], error) {
 	var result ListResponse[Card]
-	if err := c.doRequest(ctx, http.MethodGet, fmt.Sprintf("/api/sets/%d/cards", setID), nil, &result); err != nil {
+	if err := c.doRequest(ctx, "GetSetCards", http.MethodGet, fmt.Sprintf("/api/sets/%d/cards", setID), nil, &result); err != nil {
 		return nil, err
 	}
 	return &result, nil
 }
+
+// StreamSetCards iterates over all cards in a set while the response is being
+// read, so large sets are never held in memory at once. Every iteration sends
+// the request again
+func (c *Client) StreamSetCards(ctx context.Context, setID int) iter.Seq2[Card, error] {
+	return streamItems[Card](ctx, c, newOperation("StreamSetCards", http.MethodGet, fmt.Sprintf("/api/sets/%d/cards", setID), nil))
+}
@@ -139,3 +139,21 @@ func TestGetSetCards(t *testing.T) {
 	assert.Equal(t, "Test Card", result.Items[0].Name)
 	assert.Equal(t, "001", result.Items[0].Number)
 }
+
+func TestStreamSetCards(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/sets/1/cards", r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"page": 1, "items": [{"id": 1, "name": "A"}, {"id": 2, "name": "B"}, {"id": 3, "name": "C"}], "itemCount": 3}`))
+	}))
+	defer ts.Close()
+
+	client := NewClient("test-api-key", WithBaseURL(ts.URL))
+
+	var names []string
+	for card, err := range client.StreamSetCards(context.Background(), 1) {
+		assert.NoError(t, err)
+		names = append(names, card.Name)
+	}
+	assert.Equal(t, []string{"A", "B", "C"}, names)
+}
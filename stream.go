@@ -0,0 +1,181 @@
+package tcgcollector
+
+import (
+	"bufio"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"net/http"
+	"strings"
+)
+
+// gzipBody decompresses a response body and closes both readers
+type gzipBody struct {
+	*gzip.Reader
+	body io.ReadCloser
+}
+
+// Close closes the decompressor and the underlying body
+func (b *gzipBody) Close() error {
+	return errors.Join(b.Reader.Close(), b.body.Close())
+}
+
+// decompress replaces the body of a gzip-encoded response with one that
+// decompresses it. The client asks for gzip itself, so the HTTP transport
+// leaves the body compressed
+func decompress(httpResp *http.Response) error {
+	if !strings.EqualFold(httpResp.Header.Get("Content-Encoding"), "gzip") {
+		return nil
+	}
+
+	zr, err := gzip.NewReader(httpResp.Body)
+	switch {
+	case errors.Is(err, io.EOF):
+		// An empty body, e.g. of a 204 response, has nothing to decompress
+		httpResp.Body.Close()
+		httpResp.Body = http.NoBody
+	case err != nil:
+		return fmt.Errorf("failed to decompress response: %w", err)
+	default:
+		httpResp.Body = &gzipBody{Reader: zr, body: httpResp.Body}
+	}
+
+	// Content-MD5 is the digest of the encoded body
+	httpResp.Header.Del("Content-Encoding")
+	httpResp.Header.Del("Content-Length")
+	httpResp.Header.Del("Content-MD5")
+	httpResp.ContentLength = -1
+	httpResp.Uncompressed = true
+	return nil
+}
+
+// streamResponse passes the body of a successful response to the stream of op
+// and closes it. The returned response has no body
+func streamResponse(httpResp *http.Response, op *Operation) (*Response, error) {
+	defer httpResp.Body.Close()
+
+	response := &Response{
+		StatusCode: httpResp.StatusCode,
+		Header:     httpResp.Header,
+	}
+	if err := decompress(httpResp); err != nil {
+		return response, err
+	}
+	if op.download {
+		return response, op.stream(httpResp.Body, httpResp.Header)
+	}
+
+	body := bufio.NewReaderSize(httpResp.Body, maxErrorBodySnippet)
+	head, err := body.Peek(maxErrorBodySnippet)
+	if err != nil && err != io.EOF {
+		return response, fmt.Errorf("failed to read response: %w", err)
+	}
+	if hasNoContent(httpResp.StatusCode, head) && err == io.EOF {
+		return response, nil
+	}
+	if err := checkJSON(httpResp.StatusCode, httpResp.Header, head); err != nil {
+		return response, fmt.Errorf("failed to decode response: %w", err)
+	}
+	return response, op.stream(body, httpResp.Header)
+}
+
+// doStream performs op and passes the body of its response to stream without
+// buffering it. Streamed requests run through the middleware, rate limiter,
+// circuit breaker and retry policy, but are never cached or coalesced, and
+// are not retried once the API has responded successfully. The HTTP timeout
+// only bounds the wait for the response headers
+func (c *Client) doStream(ctx context.Context, op *Operation, stream func(r io.Reader, header http.Header) error) error {
+	op.stream = stream
+	_, err := c.perform(ctx, op, c.roundTrip)
+	return err
+}
+
+// streamItems returns an iterator over the elements of the items array of
+// the list response to op. Every iteration performs the request again
+func streamItems[T any](ctx context.Context, c *Client, op *Operation) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		run := *op
+		run.Header = op.Header.Clone()
+
+		stopped := false
+		err := c.doStream(ctx, &run, func(r io.Reader, _ http.Header) error {
+			err := decodeItems(r, func(item T) bool {
+				stopped = !yield(item, nil)
+				return !stopped
+			})
+			if err != nil {
+				return fmt.Errorf("failed to decode response: %w", err)
+			}
+			return nil
+		})
+		if err != nil && !stopped {
+			var zero T
+			yield(zero, err)
+		}
+	}
+}
+
+// decodeItems decodes the elements of the items array of a list response one
+// at a time and passes them to yield until it returns false. Other fields of
+// the response are skipped
+func decodeItems[T any](r io.Reader, yield func(T) bool) error {
+	dec := json.NewDecoder(r)
+	if err := expectDelim(dec, '{'); err != nil {
+		return err
+	}
+
+	for dec.More() {
+		token, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		if key, _ := token.(string); key != "items" {
+			var skipped json.RawMessage
+			if err := dec.Decode(&skipped); err != nil {
+				return err
+			}
+			continue
+		}
+
+		token, err = dec.Token()
+		if err != nil {
+			return err
+		}
+		if token == nil {
+			continue
+		}
+		if delim, ok := token.(json.Delim); !ok || delim != '[' {
+			return fmt.Errorf("items is %v, not an array", token)
+		}
+		for dec.More() {
+			var item T
+			if err := dec.Decode(&item); err != nil {
+				return err
+			}
+			if !yield(item) {
+				return nil
+			}
+		}
+		if _, err := dec.Token(); err != nil {
+			return err
+		}
+	}
+
+	return expectDelim(dec, '}')
+}
+
+// expectDelim reads the next token and checks that it is delim
+func expectDelim(dec *json.Decoder, delim json.Delim) error {
+	token, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if d, ok := token.(json.Delim); !ok || d != delim {
+		return fmt.Errorf("expected %v, got %v", delim, token)
+	}
+	return nil
+}
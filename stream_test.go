@@ -0,0 +1,214 @@
+package tcgcollector
+
+import (
+	"compress/gzip"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecodeItems(t *testing.T) {
+	var ids []int
+	err := decodeItems(strings.NewReader(`{"page": 1, "meta": {"items": [9]}, "items": [{"id": 1}, {"id": 2}], "pageCount": 1}`), func(card Card) bool {
+		ids = append(ids, card.ID)
+		return true
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1, 2}, ids)
+
+	err = decodeItems(strings.NewReader(`{"items": null}`), func(Card) bool {
+		t.Fatal("unexpected item")
+		return true
+	})
+	assert.NoError(t, err)
+
+	err = decodeItems(strings.NewReader(`{"items": "none"}`), func(Card) bool { return true })
+	assert.Error(t, err)
+
+	err = decodeItems(strings.NewReader(`[1, 2]`), func(Card) bool { return true })
+	assert.Error(t, err)
+}
+
+func TestDecodeItemsStopsEarly(t *testing.T) {
+	var ids []int
+	// The truncated rest of the body is never read
+	err := decodeItems(strings.NewReader(`{"items": [{"id": 1}, {"id": 2}, {"id": `), func(card Card) bool {
+		ids = append(ids, card.ID)
+		return len(ids) < 2
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1, 2}, ids)
+}
+
+func TestStreamDecodeError(t *testing.T) {
+	var attempts int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&attempts, 1)
+		w.Write([]byte(`{"items": [{"id": 1}, {"id": `))
+	}))
+	defer ts.Close()
+
+	client := NewClient("test-api-key", WithBaseURL(ts.URL), WithRetryPolicy(testRetryPolicy()))
+
+	var ids []int
+	var errs []error
+	for card, err := range client.StreamSetCards(context.Background(), 1) {
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		ids = append(ids, card.ID)
+	}
+	assert.Equal(t, []int{1}, ids)
+	if assert.Len(t, errs, 1) {
+		assert.Contains(t, errs[0].Error(), "failed to decode response")
+	}
+	// Items were already delivered, so the request is not retried
+	assert.Equal(t, int32(1), atomic.LoadInt32(&attempts))
+}
+
+func TestStreamAPIError(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"message": "set not found"}`))
+	}))
+	defer ts.Close()
+
+	client := NewClient("test-api-key", WithBaseURL(ts.URL))
+
+	var errs []error
+	for _, err := range client.StreamSetCards(context.Background(), 1) {
+		errs = append(errs, err)
+	}
+	if assert.Len(t, errs, 1) {
+		assert.True(t, IsNotFound(errs[0]))
+	}
+}
+
+func TestStreamTimeoutExcludesConsumer(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"items": [{"id": 1}, {"id": 2}, {"id": 3}]}`))
+	}))
+	defer ts.Close()
+
+	client := NewClient("test-api-key", WithBaseURL(ts.URL), WithHTTPClient(&http.Client{Timeout: 50 * time.Millisecond}))
+
+	// A slow consumer takes longer than the timeout without failing the stream
+	var ids []int
+	for card, err := range client.StreamSetCards(context.Background(), 1) {
+		assert.NoError(t, err)
+		ids = append(ids, card.ID)
+		time.Sleep(30 * time.Millisecond)
+	}
+	assert.Equal(t, []int{1, 2, 3}, ids)
+}
+
+func TestStreamHeaderTimeout(t *testing.T) {
+	release := make(chan struct{})
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer ts.Close()
+	defer close(release)
+
+	client := NewClient("test-api-key", WithBaseURL(ts.URL), WithHTTPClient(&http.Client{Timeout: 20 * time.Millisecond}))
+
+	var errs []error
+	for _, err := range client.StreamSetCards(context.Background(), 1) {
+		errs = append(errs, err)
+	}
+	if assert.Len(t, errs, 1) {
+		assert.ErrorIs(t, errs[0], errHeaderTimeout)
+		assert.EqualError(t, errs[0], "failed to send request: timeout awaiting response headers after 20ms")
+	}
+}
+
+func TestStreamBypassesResponseCache(t *testing.T) {
+	var requests int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.Write([]byte(`{"items": [{"id": 1}]}`))
+	}))
+	defer ts.Close()
+
+	client := NewClient("test-api-key", WithBaseURL(ts.URL), WithResponseCache(ResponseCacheConfig{TTL: time.Minute}))
+
+	for range 2 {
+		for card, err := range client.StreamSetCards(context.Background(), 1) {
+			assert.NoError(t, err)
+			assert.Equal(t, 1, card.ID)
+		}
+	}
+	assert.Equal(t, int32(2), atomic.LoadInt32(&requests))
+}
+
+func TestStreamEmptyResponse(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer ts.Close()
+
+	client := NewClient("test-api-key", WithBaseURL(ts.URL))
+	for _, err := range client.StreamSetCards(context.Background(), 1) {
+		t.Fatalf("unexpected item or error: %v", err)
+	}
+}
+
+func TestGzipResponse(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "gzip", r.Header.Get("Accept-Encoding"))
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Content-Encoding", "gzip")
+		zw := gzip.NewWriter(w)
+		zw.Write([]byte(`{"id": 1, "name": "Test Card", "items": [{"id": 1}, {"id": 2}]}`))
+		zw.Close()
+	}))
+	defer ts.Close()
+
+	client := NewClient("test-api-key", WithBaseURL(ts.URL))
+
+	card, err := client.GetCard(context.Background(), 1)
+	assert.NoError(t, err)
+	assert.Equal(t, "Test Card", card.Name)
+
+	var ids []int
+	for card, err := range client.StreamSetCards(context.Background(), 1) {
+		assert.NoError(t, err)
+		ids = append(ids, card.ID)
+	}
+	assert.Equal(t, []int{1, 2}, ids)
+}
+
+func TestGzipEmptyResponse(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Encoding", "gzip")
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer ts.Close()
+
+	client := NewClient("test-api-key", WithBaseURL(ts.URL))
+	err := client.doRequest(context.Background(), "Test", http.MethodDelete, "/api/cards/1", nil, nil)
+	assert.NoError(t, err)
+}
+
+func TestGzipInvalidResponse(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Encoding", "gzip")
+		w.Write([]byte(`{"id": 1}`))
+	}))
+	defer ts.Close()
+
+	client := NewClient("test-api-key", WithBaseURL(ts.URL))
+	_, err := client.GetCard(context.Background(), 1)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to decompress response")
+}
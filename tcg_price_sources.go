@@ -3,6 +3,7 @@ package tcgcollector
 import (
 	"context"
 	"fmt"
+	"iter"
 	"net/http"
 	"net/url"
 	"time"
@@ -15,13 +16,7 @@ type ListTCGPriceSourcesParams struct {
 }
 
 // ListTCGPriceSourcesResponse represents the response from listing TCG price sources
-type ListTCGPriceSourcesResponse struct {
-	Items          []TCGPriceSource `json:"items"`
-	ItemCount      int              `json:"itemCount"`
-	TotalItemCount int              `json:"totalItemCount"`
-	Page           int              `json:"page"`
-	PageCount      int              `json:"pageCount"`
-}
+type ListTCGPriceSourcesResponse = ListResponse[TCGPriceSource]
 
 // TCGPriceSource represents a TCG price source
 type TCGPriceSource struct {
@@ -49,17 +44,30 @@ func (c *Client) ListTCGPriceSources(ctx context.Context, params *ListTCGPriceSo
 	}
 
 	var response ListTCGPriceSourcesResponse
-	if err := c.doRequest(ctx, http.MethodGet, path, nil, &response); err != nil {
+	if err := c.doRequest(ctx, "ListTCGPriceSources", http.MethodGet, path, nil, &response); err != nil {
 		return nil, err
 	}
 
 	return &response, nil
 }
 
+// AllTCGPriceSources iterates over all TCG price sources, fetching pages as needed
+func (c *Client) AllTCGPriceSources(ctx context.Context, params *ListTCGPriceSourcesParams, opts ...PageOption) iter.Seq2[TCGPriceSource, error] {
+	var p ListTCGPriceSourcesParams
+	if params != nil {
+		p = *params
+	}
+	return paginate(ctx, p.Page, func(ctx context.Context, page int) (Page[TCGPriceSource], error) {
+		pageParams := p
+		pageParams.Page = &page
+		return c.ListTCGPriceSources(ctx, &pageParams)
+	}, opts...)
+}
+
 // GetTCGPriceSource retrieves a single TCG price source by ID
 func (c *Client) GetTCGPriceSource(ctx context.Context, id int) (*TCGPriceSource, error) {
 	var response TCGPriceSource
-	if err := c.doRequest(ctx, http.MethodGet, fmt.Sprintf("/api/tcg-price-sources/%d", id), nil, &response); err != nil {
+	if err := c.doRequest(ctx, "GetTCGPriceSource", http.MethodGet, fmt.Sprintf("/api/tcg-price-sources/%d", id), nil, &response); err != nil {
 		return nil, err
 	}
 
@@ -3,6 +3,7 @@ package tcgcollector
 import (
 	"context"
 	"fmt"
+	"iter"
 	"net/http"
 	"net/url"
 	"time"
@@ -15,13 +16,7 @@ type ListTCGRegionsParams struct {
 }
 
 // ListTCGRegionsResponse represents the response from listing TCG regions
-type ListTCGRegionsResponse struct {
-	Items          []TCGRegion `json:"items"`
-	ItemCount      int         `json:"itemCount"`
-	TotalItemCount int         `json:"totalItemCount"`
-	Page           int         `json:"page"`
-	PageCount      int         `json:"pageCount"`
-}
+type ListTCGRegionsResponse = ListResponse[TCGRegion]
 
 // TCGRegion represents a TCG region
 type TCGRegion struct {
@@ -50,17 +45,30 @@ func (c *Client) ListTCGRegions(ctx context.Context, params *ListTCGRegionsParam
 	}
 
 	var response ListTCGRegionsResponse
-	if err := c.doRequest(ctx, http.MethodGet, path, nil, &response); err != nil {
+	if err := c.doRequest(ctx, "ListTCGRegions", http.MethodGet, path, nil, &response); err != nil {
 		return nil, err
 	}
 
 	return &response, nil
 }
 
+// AllTCGRegions iterates over all TCG regions, fetching pages as needed
+func (c *Client) AllTCGRegions(ctx context.Context, params *ListTCGRegionsParams, opts ...PageOption) iter.Seq2[TCGRegion, error] {
+	var p ListTCGRegionsParams
+	if params != nil {
+		p = *params
+	}
+	return paginate(ctx, p.Page, func(ctx context.Context, page int) (Page[TCGRegion], error) {
+		pageParams := p
+		pageParams.Page = &page
+		return c.ListTCGRegions(ctx, &pageParams)
+	}, opts...)
+}
+
 // GetTCGRegion retrieves a single TCG region by ID
 func (c *Client) GetTCGRegion(ctx context.Context, id int) (*TCGRegion, error) {
 	var response TCGRegion
-	if err := c.doRequest(ctx, http.MethodGet, fmt.Sprintf("/api/tcg-regions/%d", id), nil, &response); err != nil {
+	if err := c.doRequest(ctx, "GetTCGRegion", http.MethodGet, fmt.Sprintf("/api/tcg-regions/%d", id), nil, &response); err != nil {
 		return nil, err
 	}
 	return &response, nil
@@ -0,0 +1,141 @@
+package tcgcollector
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// defaultRefreshWindow is how long before expiry a session token is refreshed
+const defaultRefreshWindow = time.Minute
+
+// TokenSource supplies the bearer token sent with each request
+type TokenSource interface {
+	Token(ctx context.Context) (string, error)
+}
+
+// tokenInvalidator is implemented by token sources that can discard a token
+// rejected by the API so that the next call to Token fetches a fresh one
+type tokenInvalidator interface {
+	Invalidate(token string)
+}
+
+// StaticTokenSource is a TokenSource that always returns the same API key
+type StaticTokenSource string
+
+// Token returns the API key
+func (s StaticTokenSource) Token(ctx context.Context) (string, error) {
+	return string(s), nil
+}
+
+// WithTokenSource sets the source of the bearer token sent with each request,
+// replacing the API key passed to NewClient
+func WithTokenSource(source TokenSource) ClientOption {
+	return func(c *Client) {
+		c.tokenSource = source
+	}
+}
+
+// SessionTokenSource is a TokenSource that logs in with a username and password
+// and refreshes the JWT shortly before it expires. It is safe for concurrent use
+type SessionTokenSource struct {
+	// RefreshWindow is how long before expiry the token is refreshed. It must
+	// not be changed once the token source is in use
+	RefreshWindow time.Duration
+
+	client      *Client
+	credentials LoginRequest
+
+	mu        sync.Mutex
+	token     string
+	expiresAt time.Time
+}
+
+// NewSessionTokenSource creates a TokenSource that logs in through client.
+// The client is only used to log in and refresh tokens and should not itself
+// use the returned token source
+func NewSessionTokenSource(client *Client, username, password string) *SessionTokenSource {
+	return &SessionTokenSource{
+		RefreshWindow: defaultRefreshWindow,
+		client:        client,
+		credentials: LoginRequest{
+			Username: username,
+			Password: password,
+		},
+	}
+}
+
+// Token returns the current session token, logging in or refreshing it when needed
+func (s *SessionTokenSource) Token(ctx context.Context) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	if s.token != "" && (s.expiresAt.IsZero() || now.Before(s.expiresAt.Add(-s.RefreshWindow))) {
+		return s.token, nil
+	}
+
+	// Refresh the token while it is still valid and fall back to logging in again
+	if s.token != "" && now.Before(s.expiresAt) {
+		response, err := s.client.RefreshToken(WithCallOptions(withoutCallOptions(ctx), BearerToken(s.token)))
+		if err == nil {
+			s.setToken(response)
+			return s.token, nil
+		}
+	}
+
+	response, err := s.client.Login(withoutCallOptions(ctx), &s.credentials)
+	if err != nil {
+		s.token = ""
+		return "", fmt.Errorf("failed to log in: %w", err)
+	}
+	s.setToken(response)
+	return s.token, nil
+}
+
+// Invalidate discards the token if it is the current one, forcing the next call to Token to log in again
+func (s *SessionTokenSource) Invalidate(token string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.token == token {
+		s.token = ""
+		s.expiresAt = time.Time{}
+	}
+}
+
+func (s *SessionTokenSource) setToken(response *LoginResponse) {
+	s.token = response.Token
+	s.expiresAt = response.ExpiresAt
+}
+
+// token returns the bearer token for a request made with ctx
+func (c *Client) token(ctx context.Context) (string, error) {
+	if token := callOptionsFromContext(ctx).token; token != "" {
+		return token, nil
+	}
+	if c.tokenSource == nil {
+		return c.apiKey, nil
+	}
+
+	token, err := c.tokenSource.Token(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to get token: %w", err)
+	}
+	return token, nil
+}
+
+// invalidateToken discards a token rejected by the API. It reports whether the
+// token source can supply a replacement
+func (c *Client) invalidateToken(ctx context.Context, token string) bool {
+	if callOptionsFromContext(ctx).token != "" {
+		return false
+	}
+	invalidator, ok := c.tokenSource.(tokenInvalidator)
+	if !ok {
+		return false
+	}
+	invalidator.Invalidate(token)
+	return true
+}
@@ -0,0 +1,183 @@
+package tcgcollector
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// newAuthServer returns a test server that issues tokens with the given lifetime
+// and serves /api/health to requests bearing the latest token
+func newAuthServer(t *testing.T, lifetime time.Duration) (*httptest.Server, *int32, *int32) {
+	var logins, refreshes int32
+	var mu sync.Mutex
+	current := ""
+
+	issue := func(w http.ResponseWriter, prefix string, n int32) {
+		mu.Lock()
+		current = fmt.Sprintf("%s-%d", prefix, n)
+		token := current
+		mu.Unlock()
+		json.NewEncoder(w).Encode(LoginResponse{Token: token, ExpiresAt: time.Now().Add(lifetime)})
+	}
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/auth/login":
+			var request LoginRequest
+			err := json.NewDecoder(r.Body).Decode(&request)
+			assert.NoError(t, err)
+			if request.Username != "ash" || request.Password != "pikachu" {
+				w.WriteHeader(http.StatusUnauthorized)
+				w.Write([]byte(`{"message": "Invalid credentials", "code": "UNAUTHORIZED"}`))
+				return
+			}
+			issue(w, "login", atomic.AddInt32(&logins, 1))
+		case "/api/auth/refresh":
+			mu.Lock()
+			valid := r.Header.Get("Authorization") == "Bearer "+current
+			mu.Unlock()
+			if !valid {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			issue(w, "refresh", atomic.AddInt32(&refreshes, 1))
+		default:
+			mu.Lock()
+			valid := r.Header.Get("Authorization") == "Bearer "+current
+			mu.Unlock()
+			if !valid {
+				w.WriteHeader(http.StatusUnauthorized)
+				w.Write([]byte(`{"message": "Invalid token", "code": "UNAUTHORIZED"}`))
+				return
+			}
+			w.Write([]byte(`{"status": "ok"}`))
+		}
+	}))
+	return ts, &logins, &refreshes
+}
+
+func TestStaticTokenSource(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer other-key", r.Header.Get("Authorization"))
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer ts.Close()
+
+	client := NewClient("test-api-key", WithBaseURL(ts.URL), WithTokenSource(StaticTokenSource("other-key")))
+	err := client.doRequest(context.Background(), "Test", http.MethodGet, "/test", nil, nil)
+	assert.NoError(t, err)
+}
+
+func TestSessionTokenSource(t *testing.T) {
+	ts, logins, refreshes := newAuthServer(t, time.Hour)
+	defer ts.Close()
+
+	source := NewSessionTokenSource(NewClient("", WithBaseURL(ts.URL)), "ash", "pikachu")
+	client := NewClient("", WithBaseURL(ts.URL), WithTokenSource(source))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			health, err := client.GetHealth(context.Background())
+			assert.NoError(t, err)
+			assert.Equal(t, "ok", health.Status)
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(logins))
+	assert.Equal(t, int32(0), atomic.LoadInt32(refreshes))
+}
+
+func TestSessionTokenSourceRefreshesBeforeExpiry(t *testing.T) {
+	ts, logins, refreshes := newAuthServer(t, time.Hour)
+	defer ts.Close()
+
+	source := NewSessionTokenSource(NewClient("", WithBaseURL(ts.URL)), "ash", "pikachu")
+	source.RefreshWindow = 2 * time.Hour
+
+	token, err := source.Token(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, "login-1", token)
+
+	// The token is within the refresh window, so it is refreshed with the current token
+	token, err = source.Token(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, "refresh-1", token)
+	assert.Equal(t, int32(1), atomic.LoadInt32(logins))
+	assert.Equal(t, int32(1), atomic.LoadInt32(refreshes))
+}
+
+func TestSessionTokenSourceLogsInAfterExpiry(t *testing.T) {
+	ts, logins, refreshes := newAuthServer(t, -time.Minute)
+	defer ts.Close()
+
+	source := NewSessionTokenSource(NewClient("", WithBaseURL(ts.URL)), "ash", "pikachu")
+
+	_, err := source.Token(context.Background())
+	assert.NoError(t, err)
+	token, err := source.Token(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, "login-2", token)
+	assert.Equal(t, int32(2), atomic.LoadInt32(logins))
+	assert.Equal(t, int32(0), atomic.LoadInt32(refreshes))
+}
+
+func TestSessionTokenSourceRetriesUnauthorized(t *testing.T) {
+	ts, logins, _ := newAuthServer(t, time.Hour)
+	defer ts.Close()
+
+	authClient := NewClient("", WithBaseURL(ts.URL))
+	source := NewSessionTokenSource(authClient, "ash", "pikachu")
+	client := NewClient("", WithBaseURL(ts.URL), WithTokenSource(source))
+
+	_, err := client.GetHealth(context.Background())
+	assert.NoError(t, err)
+
+	// Another session logs in and the server revokes the cached token
+	_, err = authClient.Login(context.Background(), &LoginRequest{Username: "ash", Password: "pikachu"})
+	assert.NoError(t, err)
+
+	health, err := client.GetHealth(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, "ok", health.Status)
+	assert.Equal(t, int32(3), atomic.LoadInt32(logins))
+}
+
+func TestSessionTokenSourceLoginError(t *testing.T) {
+	ts, _, _ := newAuthServer(t, time.Hour)
+	defer ts.Close()
+
+	source := NewSessionTokenSource(NewClient("", WithBaseURL(ts.URL)), "ash", "wrong")
+	client := NewClient("", WithBaseURL(ts.URL), WithTokenSource(source))
+
+	_, err := client.GetHealth(context.Background())
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to get token: failed to log in")
+	assert.True(t, IsUnauthorized(err))
+}
+
+func TestUnauthorizedWithStaticTokenIsNotRetried(t *testing.T) {
+	var requests int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer ts.Close()
+
+	client := NewClient("test-api-key", WithBaseURL(ts.URL))
+	_, err := client.GetHealth(context.Background())
+	assert.True(t, IsUnauthorized(err))
+	assert.Equal(t, int32(1), atomic.LoadInt32(&requests))
+}
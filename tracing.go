@@ -0,0 +1,129 @@
+package tcgcollector
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strings"
+)
+
+// Tracer starts spans around operations and their request attempts, so that
+// any tracing backend can be plugged into the client
+type Tracer interface {
+	// Start starts a span. The returned context is used for everything within
+	// the span; a tracer that propagates its spans to the API should store
+	// their trace context in it with ContextWithTraceContext
+	Start(ctx context.Context, name string, attrs ...slog.Attr) (context.Context, Span)
+}
+
+// Span is a span started by a Tracer
+type Span interface {
+	// SetAttributes adds attributes to the span
+	SetAttributes(attrs ...slog.Attr)
+	// End ends the span. err is the error of the spanned work, if any
+	End(err error)
+}
+
+// WithTracer starts a span for every operation and a child span for every
+// request attempt with tracer
+func WithTracer(tracer Tracer) ClientOption {
+	return func(c *Client) {
+		c.tracer = tracer
+	}
+}
+
+// TraceContext holds the W3C trace context sent with requests
+type TraceContext struct {
+	// TraceParent is the value of the traceparent header, e.g.
+	// "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+	TraceParent string
+	// TraceState is the value of the tracestate header. It is optional
+	TraceState string
+}
+
+type traceContextKey struct{}
+
+// ContextWithTraceContext returns a context whose requests carry the traceparent
+// and tracestate headers of tc
+func ContextWithTraceContext(ctx context.Context, tc TraceContext) context.Context {
+	return context.WithValue(ctx, traceContextKey{}, tc)
+}
+
+// TraceContextFromContext returns the trace context stored in ctx
+func TraceContextFromContext(ctx context.Context) (TraceContext, bool) {
+	tc, ok := ctx.Value(traceContextKey{}).(TraceContext)
+	return tc, ok
+}
+
+// injectTraceContext sets the trace context headers of req from ctx. An
+// invalid traceparent is not sent, and neither is tracestate without it
+func injectTraceContext(ctx context.Context, req *http.Request) {
+	tc, ok := TraceContextFromContext(ctx)
+	if !ok || !validTraceParent(tc.TraceParent) {
+		return
+	}
+	req.Header.Set("traceparent", tc.TraceParent)
+	if tc.TraceState != "" {
+		req.Header.Set("tracestate", tc.TraceState)
+	}
+}
+
+// validTraceParent reports whether s has the traceparent format
+// version-traceid-parentid-flags with lowercase hex fields of 2, 32, 16 and 2
+// digits and a non-zero trace and parent ID
+func validTraceParent(s string) bool {
+	fields := strings.Split(s, "-")
+	if len(fields) < 4 || fields[0] == "ff" {
+		return false
+	}
+	for i, n := range []int{2, 32, 16, 2} {
+		if len(fields[i]) != n || strings.Trim(fields[i], "0123456789abcdef") != "" {
+			return false
+		}
+	}
+	return strings.Trim(fields[1], "0") != "" && strings.Trim(fields[2], "0") != ""
+}
+
+// noopSpan is the Span used when the client has no tracer
+type noopSpan struct{}
+
+func (noopSpan) SetAttributes(attrs ...slog.Attr) {}
+func (noopSpan) End(err error)                    {}
+
+// startOperationSpan starts the span of an operation
+func (c *Client) startOperationSpan(ctx context.Context, op *Operation) (context.Context, Span) {
+	if c.tracer == nil {
+		return ctx, noopSpan{}
+	}
+	return c.tracer.Start(ctx, "tcgcollector."+op.Name,
+		slog.String("operation", op.Name),
+		slog.String("http.method", op.Method),
+		slog.String("http.route", op.PathTemplate),
+	)
+}
+
+// startAttemptSpan starts the span of a request attempt
+func (c *Client) startAttemptSpan(ctx context.Context, op *Operation, attempt int) (context.Context, Span) {
+	if c.tracer == nil {
+		return ctx, noopSpan{}
+	}
+	return c.tracer.Start(ctx, "HTTP "+op.Method,
+		slog.String("operation", op.Name),
+		slog.String("http.method", op.Method),
+		slog.String("http.route", op.PathTemplate),
+		slog.Int("retry.attempt", attempt+1),
+	)
+}
+
+// endSpan adds the status code of the response or error to span and ends it
+func endSpan(span Span, resp *Response, err error) {
+	var apiErr *APIError
+	switch {
+	case resp != nil:
+		span.SetAttributes(slog.Int("http.status_code", resp.StatusCode))
+	case errors.As(err, &apiErr):
+		span.SetAttributes(slog.Int("http.status_code", apiErr.StatusCode))
+	}
+	span.End(err)
+}
@@ -0,0 +1,134 @@
+package tcgcollector
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// recordingTracer is a Tracer that records its spans and propagates them
+// with trace context derived from their index
+type recordingTracer struct {
+	mu    sync.Mutex
+	spans []*recordedSpan
+}
+
+type recordedSpan struct {
+	name   string
+	parent string
+	attrs  map[string]slog.Value
+	ended  bool
+	err    error
+}
+
+func (s *recordedSpan) SetAttributes(attrs ...slog.Attr) {
+	for _, attr := range attrs {
+		s.attrs[attr.Key] = attr.Value
+	}
+}
+
+func (s *recordedSpan) End(err error) {
+	s.ended = true
+	s.err = err
+}
+
+func (r *recordingTracer) Start(ctx context.Context, name string, attrs ...slog.Attr) (context.Context, Span) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	span := &recordedSpan{name: name, attrs: make(map[string]slog.Value)}
+	if tc, ok := TraceContextFromContext(ctx); ok {
+		span.parent = tc.TraceParent
+	}
+	span.SetAttributes(attrs...)
+	r.spans = append(r.spans, span)
+
+	traceParent := fmt.Sprintf("00-4bf92f3577b34da6a3ce929d0e0e4736-%016x-01", len(r.spans))
+	return ContextWithTraceContext(ctx, TraceContext{TraceParent: traceParent, TraceState: "sdk=test"}), span
+}
+
+func TestTraceContextPropagation(t *testing.T) {
+	var headers []http.Header
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		headers = append(headers, r.Header.Clone())
+		w.Write([]byte(`{"status": "ok"}`))
+	}))
+	defer ts.Close()
+
+	client := NewClient("test-api-key", WithBaseURL(ts.URL))
+	traceParent := "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+	ctx := ContextWithTraceContext(context.Background(), TraceContext{TraceParent: traceParent, TraceState: "vendor=value"})
+	_, err := client.GetHealth(ctx)
+	assert.NoError(t, err)
+
+	// Invalid trace context is not propagated
+	ctx = ContextWithTraceContext(context.Background(), TraceContext{TraceParent: "00-invalid-01", TraceState: "vendor=value"})
+	_, err = client.GetHealth(ctx)
+	assert.NoError(t, err)
+
+	assert.Equal(t, traceParent, headers[0].Get("traceparent"))
+	assert.Equal(t, "vendor=value", headers[0].Get("tracestate"))
+	assert.Empty(t, headers[1].Get("traceparent"))
+	assert.Empty(t, headers[1].Get("tracestate"))
+}
+
+func TestValidTraceParent(t *testing.T) {
+	assert.True(t, validTraceParent("00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"))
+	assert.False(t, validTraceParent("00-4BF92F3577B34DA6A3CE929D0E0E4736-00f067aa0ba902b7-01"))
+	assert.False(t, validTraceParent("00-00000000000000000000000000000000-00f067aa0ba902b7-01"))
+	assert.False(t, validTraceParent("00-4bf92f3577b34da6a3ce929d0e0e4736-0000000000000000-01"))
+	assert.False(t, validTraceParent("ff-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"))
+	assert.False(t, validTraceParent("00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7"))
+}
+
+func TestTracerSpans(t *testing.T) {
+	var traceParents []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		traceParents = append(traceParents, r.Header.Get("traceparent"))
+		if len(traceParents) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`{"id": 1}`))
+	}))
+	defer ts.Close()
+
+	tracer := &recordingTracer{}
+	client := NewClient("test-api-key",
+		WithBaseURL(ts.URL),
+		WithTracer(tracer),
+		WithRetryPolicy(&RetryPolicy{MaxRetries: 1, BaseDelay: time.Millisecond, MaxDelay: time.Millisecond}),
+	)
+	_, err := client.GetCard(context.Background(), 1)
+	assert.NoError(t, err)
+
+	assert.Len(t, tracer.spans, 3)
+	operation, first, second := tracer.spans[0], tracer.spans[1], tracer.spans[2]
+
+	assert.Equal(t, "tcgcollector.GetCard", operation.name)
+	assert.Equal(t, "GetCard", operation.attrs["operation"].String())
+	assert.Equal(t, "/api/cards/{id}", operation.attrs["http.route"].String())
+	assert.Equal(t, int64(200), operation.attrs["http.status_code"].Int64())
+	assert.True(t, operation.ended)
+	assert.NoError(t, operation.err)
+
+	// Attempt spans are children of the operation span and are propagated to the API
+	for i, span := range []*recordedSpan{first, second} {
+		assert.Equal(t, "HTTP GET", span.name)
+		assert.Equal(t, "00-4bf92f3577b34da6a3ce929d0e0e4736-0000000000000001-01", span.parent)
+		assert.Equal(t, int64(i+1), span.attrs["retry.attempt"].Int64())
+		assert.Equal(t, fmt.Sprintf("00-4bf92f3577b34da6a3ce929d0e0e4736-%016x-01", i+2), traceParents[i])
+		assert.True(t, span.ended)
+	}
+	assert.Equal(t, int64(503), first.attrs["http.status_code"].Int64())
+	assert.True(t, IsServerError(first.err))
+	assert.Equal(t, int64(200), second.attrs["http.status_code"].Int64())
+	assert.NoError(t, second.err)
+}
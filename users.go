@@ -3,6 +3,7 @@ package tcgcollector
 import (
 	"context"
 	"fmt"
+	"iter"
 	"net/http"
 	"net/url"
 )
@@ -15,13 +16,7 @@ type ListUsersParams struct {
 }
 
 // ListUsersResponse represents the response from listing users
-type ListUsersResponse struct {
-	Items          []User `json:"items"`
-	ItemCount      int    `json:"itemCount"`
-	TotalItemCount int    `json:"totalItemCount"`
-	Page           int    `json:"page"`
-	PageCount      int    `json:"pageCount"`
-}
+type ListUsersResponse = ListResponse[User]
 
 // CreateUserParams contains the parameters for creating a user
 type CreateUserParams struct {
@@ -57,17 +52,30 @@ func (c *Client) ListUsers(ctx context.Context, params *ListUsersParams) (*ListU
 	}
 
 	var response ListUsersResponse
-	if err := c.doRequest(ctx, http.MethodGet, path, nil, &response); err != nil {
+	if err := c.doRequest(ctx, "ListUsers", http.MethodGet, path, nil, &response); err != nil {
 		return nil, err
 	}
 
 	return &response, nil
 }
 
+// AllUsers iterates over all users matching params, fetching pages as needed
+func (c *Client) AllUsers(ctx context.Context, params *ListUsersParams, opts ...PageOption) iter.Seq2[User, error] {
+	var p ListUsersParams
+	if params != nil {
+		p = *params
+	}
+	return paginate(ctx, p.Page, func(ctx context.Context, page int) (Page[User], error) {
+		pageParams := p
+		pageParams.Page = &page
+		return c.ListUsers(ctx, &pageParams)
+	}, opts...)
+}
+
 // GetUser retrieves a single user by ID
 func (c *Client) GetUser(ctx context.Context, id int) (*User, error) {
 	var response User
-	if err := c.doRequest(ctx, http.MethodGet, fmt.Sprintf("/api/users/%d", id), nil, &response); err != nil {
+	if err := c.doRequest(ctx, "GetUser", http.MethodGet, fmt.Sprintf("/api/users/%d", id), nil, &response); err != nil {
 		return nil, err
 	}
 
@@ -77,7 +85,7 @@ func (c *Client) GetUser(ctx context.Context, id int) (*User, error) {
 // CreateUser creates a new user
 func (c *Client) CreateUser(ctx context.Context, params *CreateUserParams) (*User, error) {
 	var response User
-	if err := c.doRequest(ctx, http.MethodPost, "/api/users", params, &response); err != nil {
+	if err := c.doIdempotentRequest(ctx, "CreateUser", http.MethodPost, "/api/users", params, &response); err != nil {
 		return nil, err
 	}
 
@@ -87,7 +95,7 @@ func (c *Client) CreateUser(ctx context.Context, params *CreateUserParams) (*Use
 // UpdateUser updates an existing user
 func (c *Client) UpdateUser(ctx context.Context, id int, params *UpdateUserParams) (*User, error) {
 	var response User
-	if err := c.doRequest(ctx, http.MethodPut, fmt.Sprintf("/api/users/%d", id), params, &response); err != nil {
+	if err := c.doRequest(ctx, "UpdateUser", http.MethodPut, fmt.Sprintf("/api/users/%d", id), params, &response); err != nil {
 		return nil, err
 	}
 
@@ -96,13 +104,13 @@ func (c *Client) UpdateUser(ctx context.Context, id int, params *UpdateUserParam
 
 // DeleteUser deletes a user
 func (c *Client) DeleteUser(ctx context.Context, id int) error {
-	return c.doRequest(ctx, http.MethodDelete, fmt.Sprintf("/api/users/%d", id), nil, nil)
+	return c.doRequest(ctx, "DeleteUser", http.MethodDelete, fmt.Sprintf("/api/users/%d", id), nil, nil)
 }
 
 // GetCurrentUser retrieves the currently authenticated user
 func (c *Client) GetCurrentUser(ctx context.Context) (*User, error) {
 	var response User
-	if err := c.doRequest(ctx, http.MethodGet, "/api/users/me", nil, &response); err != nil {
+	if err := c.doRequest(ctx, "GetCurrentUser", http.MethodGet, "/api/users/me", nil, &response); err != nil {
 		return nil, err
 	}
 
@@ -112,7 +120,7 @@ func (c *Client) GetCurrentUser(ctx context.Context) (*User, error) {
 // UpdateCurrentUser updates the currently authenticated user
 func (c *Client) UpdateCurrentUser(ctx context.Context, params *UpdateUserParams) (*User, error) {
 	var response User
-	if err := c.doRequest(ctx, http.MethodPut, "/api/users/me", params, &response); err != nil {
+	if err := c.doRequest(ctx, "UpdateCurrentUser", http.MethodPut, "/api/users/me", params, &response); err != nil {
 		return nil, err
 	}
 
@@ -121,13 +129,13 @@ func (c *Client) UpdateCurrentUser(ctx context.Context, params *UpdateUserParams
 
 // DeleteCurrentUser deletes the currently authenticated user
 func (c *Client) DeleteCurrentUser(ctx context.Context) error {
-	return c.doRequest(ctx, http.MethodDelete, "/api/users/me", nil, nil)
+	return c.doRequest(ctx, "DeleteCurrentUser", http.MethodDelete, "/api/users/me", nil, nil)
 }
 
 // GetUserPreferences gets a user's preferences
 func (c *Client) GetUserPreferences(ctx context.Context, userID int) (*UserPreferences, error) {
 	var result UserPreferences
-	if err := c.doRequest(ctx, http.MethodGet, fmt.Sprintf("/api/users/%d/preferences", userID), nil, &result); err != nil {
+	if err := c.doRequest(ctx, "GetUserPreferences", http.MethodGet, fmt.Sprintf("/api/users/%d/preferences", userID), nil, &result); err != nil {
 		return nil, err
 	}
 	return &result, nil
@@ -136,7 +144,7 @@ func (c *Client) GetUserPreferences(ctx context.Context, userID int) (*UserPrefe
 // UpdateUserPreferences updates a user's preferences
 func (c *Client) UpdateUserPreferences(ctx context.Context, userID int, preferences *UserPreferences) (*UserPreferences, error) {
 	var result UserPreferences
-	if err := c.doRequest(ctx, http.MethodPut, fmt.Sprintf("/api/users/%d/preferences", userID), preferences, &result); err != nil {
+	if err := c.doRequest(ctx, "UpdateUserPreferences", http.MethodPut, fmt.Sprintf("/api/users/%d/preferences", userID), preferences, &result); err != nil {
 		return nil, err
 	}
 	return &result, nil
@@ -147,7 +155,7 @@ func (c *Client) GetUserCount(ctx context.Context) (int, error) {
 	var response struct {
 		Count int `json:"count"`
 	}
-	if err := c.doRequest(ctx, http.MethodGet, "/api/users/count", nil, &response); err != nil {
+	if err := c.doRequest(ctx, "GetUserCount", http.MethodGet, "/api/users/count", nil, &response); err != nil {
 		return 0, err
 	}
 	return response.Count, nil
@@ -155,17 +163,17 @@ func (c *Client) GetUserCount(ctx context.Context) (int, error) {
 
 // PruneActivityLogs prunes user activity logs
 func (c *Client) PruneActivityLogs(ctx context.Context) error {
-	return c.doRequest(ctx, http.MethodPost, "/api/users/prune-activity-logs", nil, nil)
+	return c.doRequest(ctx, "PruneActivityLogs", http.MethodPost, "/api/users/prune-activity-logs", nil, nil)
 }
 
 // DisableUserPremium disables premium features for a user
 func (c *Client) DisableUserPremium(ctx context.Context, userID int) error {
-	return c.doRequest(ctx, http.MethodPost, fmt.Sprintf("/api/users/%d/disable-premium", userID), nil, nil)
+	return c.doRequest(ctx, "DisableUserPremium", http.MethodPost, fmt.Sprintf("/api/users/%d/disable-premium", userID), nil, nil)
 }
 
 // EnableUserPremiumWithoutSubscription enables premium features for a user without requiring a subscription
 func (c *Client) EnableUserPremiumWithoutSubscription(ctx context.Context, userID int) error {
-	return c.doRequest(ctx, http.MethodPost, fmt.Sprintf("/api/users/%d/enable-premium-without-subscription", userID), nil, nil)
+	return c.doRequest(ctx, "EnableUserPremiumWithoutSubscription", http.MethodPost, fmt.Sprintf("/api/users/%d/enable-premium-without-subscription", userID), nil, nil)
 }
 
 // GenerateAPIAccessToken generates a new API access token for a user
@@ -173,7 +181,7 @@ func (c *Client) GenerateAPIAccessToken(ctx context.Context, userID int) (string
 	var response struct {
 		Token string `json:"token"`
 	}
-	if err := c.doRequest(ctx, http.MethodPost, fmt.Sprintf("/api/users/%d/generate-api-access-token", userID), nil, &response); err != nil {
+	if err := c.doRequest(ctx, "GenerateAPIAccessToken", http.MethodPost, fmt.Sprintf("/api/users/%d/generate-api-access-token", userID), nil, &response); err != nil {
 		return "", err
 	}
 	return response.Token, nil
@@ -184,7 +192,7 @@ func (c *Client) GetUserPermissions(ctx context.Context, userID int) ([]string,
 	var response struct {
 		Permissions []string `json:"permissions"`
 	}
-	if err := c.doRequest(ctx, http.MethodGet, fmt.Sprintf("/api/users/%d/permissions", userID), nil, &response); err != nil {
+	if err := c.doRequest(ctx, "GetUserPermissions", http.MethodGet, fmt.Sprintf("/api/users/%d/permissions", userID), nil, &response); err != nil {
 		return nil, err
 	}
 	return response.Permissions, nil
@@ -192,5 +200,5 @@ func (c *Client) GetUserPermissions(ctx context.Context, userID int) ([]string,
 
 // RevokeAPIAccessToken revokes the API access token for a user
 func (c *Client) RevokeAPIAccessToken(ctx context.Context, userID int) error {
-	return c.doRequest(ctx, http.MethodPost, fmt.Sprintf("/api/users/%d/revoke-api-access-token", userID), nil, nil)
+	return c.doRequest(ctx, "RevokeAPIAccessToken", http.MethodPost, fmt.Sprintf("/api/users/%d/revoke-api-access-token", userID), nil, nil)
 }